@@ -1,12 +1,31 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"log"
+
 	"github.com/abergmeier/terraform-provider-exasol/internal/resourceprovider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: resourceprovider.Provider,
-	})
+	debug := flag.Bool("debug", false, "Start provider in debug mode")
+	flag.Parse()
+
+	ctx := context.Background()
+	serverFunc, err := resourceprovider.MuxServer(ctx, resourceprovider.Provider())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var opts []tf6server.ServeOpt
+	if *debug {
+		opts = append(opts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve("registry.terraform.io/abergmeier/exasol", serverFunc, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,8 @@
+resource "exasol_sql" "audit_schema" {
+   create_sql  = <<-SQL
+      CREATE SCHEMA AUDIT_LOG;
+      COMMENT ON SCHEMA AUDIT_LOG IS 'Managed by Terraform';
+   SQL
+   destroy_sql = "DROP SCHEMA AUDIT_LOG CASCADE"
+   read_query  = "SELECT SCHEMA_COMMENT FROM SYS.EXA_SCHEMAS WHERE SCHEMA_NAME = 'AUDIT_LOG'"
+}
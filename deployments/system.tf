@@ -0,0 +1,27 @@
+// See https://docs.exasol.com/sql/alter_system.htm
+// Destroying restores the value found before the first apply
+
+resource "exasol_system_parameter" "query_timeout" {
+  name  = "QUERY_TIMEOUT"
+  value = "3600"
+}
+
+resource "exasol_system_parameter" "date_format" {
+  name  = "NLS_DATE_FORMAT"
+  value = "YYYY-MM-DD"
+}
+
+// Leaving all rules unset turns the policy OFF
+resource "exasol_password_policy" "policy" {
+  min_length                = 12
+  max_length                = 128
+  min_lower_case            = 1
+  min_upper_case            = 1
+  min_numeric_chars         = 1
+  reusable_after_days       = 180
+  max_failed_login_attempts = 5
+}
+
+output "locked_users" {
+  value = exasol_password_policy.policy.locked_users
+}
@@ -9,3 +9,15 @@ resource "exasol_user" "user_2" {
     ldap = "cn=user_2,dc=authorization,dc=exasol,dc=com"
 }
 */
+
+/* Only works when OpenID is configured (Exasol 7.1 or later)
+resource "exasol_user" "user_3" {
+    name = "user_3"
+    openid_subject = "user_3@example.com"
+}
+*/
+
+resource "exasol_impersonation_grant" "user_1_test_role" {
+    impersonation_on = exasol_role.test_role.name
+    grantee = exasol_user.user_1.name
+}
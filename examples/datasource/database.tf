@@ -0,0 +1,11 @@
+data "exasol_database" "current" {
+}
+
+locals {
+    # IMPERSONATE is available since Exasol 7.1
+    supports_impersonation = data.exasol_database.current.major_version > 7 || (data.exasol_database.current.major_version == 7 && data.exasol_database.current.minor_version >= 1)
+}
+
+output "database" {
+    value = "${data.exasol_database.current.name} ${data.exasol_database.current.version} on ${data.exasol_database.current.node_count} nodes"
+}
@@ -0,0 +1,4 @@
+data "exasol_object_dependencies" "orders" {
+    object    = "REPORTING.ORDERS"
+    max_depth = 3
+}
@@ -0,0 +1,26 @@
+data "exasol_users" "ldap" {
+    authentication = "ldap"
+}
+
+data "exasol_roles" "analysts" {
+    name_regex = "^ANALYST_"
+}
+
+data "exasol_schemas" "staging" {
+    name_regex = "^STAGING_"
+    type       = "physical"
+}
+
+output "ldap_users" {
+    value = toset(data.exasol_users.ldap.names)
+}
+
+data "exasol_tables" "staging" {
+    schema     = "STAGING"
+    name_regex = "^IMPORT_"
+}
+
+data "exasol_views" "reporting" {
+    schema        = "REPORTING"
+    comment_regex = "(?i)public"
+}
@@ -0,0 +1,9 @@
+data "exasol_query" "db_version" {
+    query      = "SELECT PARAM_VALUE FROM SYS.EXA_METADATA WHERE PARAM_NAME = ?"
+    parameters = ["databaseProductVersion"]
+    max_rows   = 1
+}
+
+output "db_version" {
+    value = data.exasol_query.db_version.rows[0]["PARAM_VALUE"]
+}
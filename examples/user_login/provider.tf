@@ -1,4 +1,4 @@
 provider "exasol" {
-  username = jsondecode(data.local_file.login.content).username
-  password = jsondecode(data.local_file.login.content).password
+  config_file = "${path.module}/.exasol_profiles"
+  profile     = "default"
 }
@@ -7,7 +7,9 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -31,12 +33,19 @@ func Resource() *schema.Resource {
 				Description: "User used with connection",
 			},
 		},
-		ReadContext: read,
 	}
 }
 
-func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*exaprovider.Client)
+// DataSourceType serves exasol_connection with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_connection",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
 	return readData(ctx, d, locked.Tx)
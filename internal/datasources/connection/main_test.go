@@ -7,8 +7,8 @@ import (
 	"os"
 	"testing"
 
-	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 )
@@ -29,7 +29,7 @@ func TestMain(m *testing.M) {
 
 func testRun(m *testing.M) int {
 
-	exaClient = internal.MustCreateTestClient()
+	exaClient = testclient.MustCreateTestClient()
 
 	func() {
 		locked := exaClient.Lock(context.TODO())
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resource returns the Datasource describing the connected Exasol database
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of database",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Product version of database",
+			},
+			"major_version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"minor_version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"node_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of cluster nodes",
+			},
+			"current_user": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_schema": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Schema opened in session. Empty if none is open.",
+			},
+			"session_parameters": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Parameters of the session as set by the provider",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// DataSourceType serves exasol_database with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_database",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	return readData(ctx, d, locked.Tx)
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) diag.Diagnostics {
+	database, err := computed.ReadDatabase(ctx, tx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	version, err := db.ParseVersion(database.Version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := map[string]interface{}{}
+	for k, v := range database.SessionParameters {
+		params[k] = v
+	}
+
+	values := map[string]interface{}{
+		"name":               database.Name,
+		"version":            database.Version,
+		"major_version":      version.Major,
+		"minor_version":      version.Minor,
+		"node_count":         database.NodeCount,
+		"current_user":       database.CurrentUser,
+		"current_schema":     database.CurrentSchema,
+		"session_parameters": params,
+	}
+	for k, v := range values {
+		err = d.Set(k, v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(database.Name)
+	return nil
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestReadDatabase(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{},
+	}
+	diags := readData(context.TODO(), read, locked.Tx)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	if read.Id() == "" {
+		t.Fatal("Expected id to be database name")
+	}
+	if read.Get("version").(string) == "" {
+		t.Fatal("Expected version to be set")
+	}
+	if read.Get("major_version").(int) < 6 {
+		t.Fatalf("Unexpected major version: %d", read.Get("major_version"))
+	}
+	if read.Get("node_count").(int) < 1 {
+		t.Fatalf("Unexpected node count: %d", read.Get("node_count"))
+	}
+	if read.Get("current_user").(string) == "" {
+		t.Fatal("Expected current user to be set")
+	}
+	params := read.Get("session_parameters").(map[string]interface{})
+	if _, ok := params["NLS_DATE_FORMAT"]; !ok {
+		t.Fatalf("Expected NLS_DATE_FORMAT in session parameters: %#v", params)
+	}
+}
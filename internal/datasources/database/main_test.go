@@ -0,0 +1,25 @@
+package database
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
+)
+
+var (
+	exaClient *exaprovider.Client
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(testRun(m))
+}
+
+func testRun(m *testing.M) int {
+	exaClient = testclient.MustCreateTestClient()
+
+	return m.Run()
+}
@@ -0,0 +1,135 @@
+package dependencies
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultMaxDepth = 10
+)
+
+// Resource returns the Datasource for dependencies of an Exasol object
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"object": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Qualified name (SCHEMA.NAME) of the object. Schema defaults to default_schema of the provider.",
+			},
+			"max_depth": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxDepth,
+				Description:  "Maximum number of levels to follow. 1 only returns direct dependencies.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"references":    dependenciesSchema("Objects referenced by object"),
+			"referenced_by": dependenciesSchema("Objects referencing object"),
+		},
+	}
+}
+
+func dependenciesSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"schema": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"depth": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// DataSourceType serves exasol_object_dependencies with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_object_dependencies",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	return readData(ctx, d, locked.Tx, c.Defaults)
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx, defaults argument.Defaults) diag.Diagnostics {
+	object, _ := d.Get("object").(string)
+	m, err := resource.GetMetaFromQNDefault(object, defaults.Schema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if strings.TrimSpace(m.Schema) == "" {
+		return diag.FromErr(errors.New("object needs a schema when the provider has no default_schema"))
+	}
+
+	maxDepth, _ := d.Get("max_depth").(int)
+	if maxDepth == 0 {
+		maxDepth = defaultMaxDepth
+	}
+
+	deps, err := computed.ReadDependencies(ctx, tx, m.Schema, m.ObjectName, maxDepth)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("references", flatten(deps.References))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("referenced_by", flatten(deps.ReferencedBy))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", resource.NewID(m.Schema, m.ObjectName), maxDepth))
+	return nil
+}
+
+func flatten(deps []computed.Dependency) []interface{} {
+	l := make([]interface{}, 0, len(deps))
+	for _, dep := range deps {
+		l = append(l, map[string]interface{}{
+			"schema": dep.Schema,
+			"name":   dep.Name,
+			"type":   dep.Type,
+			"depth":  dep.Depth,
+		})
+	}
+	return l
+}
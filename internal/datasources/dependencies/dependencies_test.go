@@ -0,0 +1,70 @@
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+)
+
+func TestReadDependencies(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+	prefix := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	stmts := []string{
+		fmt.Sprintf("CREATE TABLE %s.%s_T (A VARCHAR(20))", schemaName, prefix),
+		fmt.Sprintf("CREATE VIEW %s.%s_V1 AS SELECT A FROM %s.%s_T", schemaName, prefix, schemaName, prefix),
+		fmt.Sprintf("CREATE VIEW %s.%s_V2 AS SELECT A FROM %s.%s_V1", schemaName, prefix, schemaName, prefix),
+		// Views are only compiled and added to the dependencies on first use
+		fmt.Sprintf("SELECT * FROM %s.%s_V2", schemaName, prefix),
+	}
+	for _, stmt := range stmts {
+		_, err := locked.Tx.Exec(stmt)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"object":    prefix + "_V1",
+			"max_depth": 1,
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx, argument.Defaults{
+		Schema: schemaName,
+	})
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	references := read.Get("references").([]interface{})
+	if len(references) != 1 || references[0].(map[string]interface{})["name"] != prefix+"_T" {
+		t.Fatalf("Unexpected references: %#v", references)
+	}
+	referencedBy := read.Get("referenced_by").([]interface{})
+	if len(referencedBy) != 1 || referencedBy[0].(map[string]interface{})["name"] != prefix+"_V2" {
+		t.Fatalf("Unexpected referenced_by: %#v", referencedBy)
+	}
+}
+
+func TestReadDependenciesNotFound(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+	name := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	_, err := computed.ReadDependencies(context.TODO(), locked.Tx, schemaName, name, 1)
+	if !db.IsNotFound(err) {
+		t.Fatal("Expected not found error:", err)
+	}
+}
@@ -7,28 +7,69 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/internal/logging"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func PhysicalSchema() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+// PhysicalSchemaType is the framework implementation of
+// exasol_physical_schema
+type PhysicalSchemaType struct{}
+
+type physicalSchema struct {
+	client *exaprovider.Client
+}
+
+func (t PhysicalSchemaType) GetSchema(ctx context.Context) (tfsdk.Schema, fwdiag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
 			"name": {
-				Type:        schema.TypeString,
+				Type:        types.StringType,
 				Required:    true,
 				Description: "Name of Schema",
 			},
 		},
-		ReadContext: readPhysicalSchema,
-	}
+	}, nil
+}
+
+func (t PhysicalSchemaType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, fwdiag.Diagnostics) {
+	c, diags := framework.Client(p)
+	return &physicalSchema{
+		client: c,
+	}, diags
 }
 
-func readPhysicalSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*exaprovider.Client)
-	locked := c.Lock(ctx)
+func (ds *physicalSchema) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	ctx = logging.WithSubsystem(ctx, "data_physical_schema")
+
+	d, err := framework.NewData(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Reading config failed", err.Error())
+		return
+	}
+
+	locked := ds.client.Lock(ctx)
 	defer locked.Unlock()
-	return readPhysicalSchemaData(ctx, d, locked.Tx)
+	resp.Diagnostics.Append(framework.Diagnostics(readPhysicalSchemaData(ctx, d, locked.Tx))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	raw, err := d.Value(req.Config.Schema.TerraformType(ctx).(tftypes.Object))
+	if err != nil {
+		resp.Diagnostics.AddError("Writing state failed", err.Error())
+		return
+	}
+	resp.State.Raw = raw
 }
 
 func readPhysicalSchemaData(ctx context.Context, d internal.Data, tx *sql.Tx) diag.Diagnostics {
@@ -38,9 +79,14 @@ func readPhysicalSchemaData(ctx context.Context, d internal.Data, tx *sql.Tx) di
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	defer res.Close()
 
 	if !res.Next() {
-		return diag.Errorf("Schema %s not found", name)
+		err = res.Err()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return diag.FromErr(db.NewNotFoundError("SCHEMA", name))
 	}
 
 	d.SetId(strings.ToUpper(name))
@@ -0,0 +1,146 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/internal/statements"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultMaxRows = 1000
+)
+
+// Resource returns the Datasource running a read-only SELECT
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "SELECT statement to run. Use ? for bind parameters.",
+				ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+					q, _ := i.(string)
+					s := &statements.Select{
+						Query: q,
+					}
+					err := s.Validate()
+					if err != nil {
+						return nil, []error{fmt.Errorf("%s: %w", k, err)}
+					}
+					return nil, nil
+				},
+			},
+			"parameters": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Values for the bind parameters in query",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"max_rows": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRows,
+				Description:  "Fail if query returns more rows",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"columns": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Column names of the result",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rows of the result as maps of column name to value. NULL values are omitted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// DataSourceType serves exasol_query with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_query",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
+	locked := c.Lock(ctx)
+	// Never committed so the query cannot change anything
+	defer locked.Unlock()
+	return readData(ctx, d, locked.Tx)
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) diag.Diagnostics {
+	q, _ := d.Get("query").(string)
+	maxRows, _ := d.Get("max_rows").(int)
+	if maxRows == 0 {
+		maxRows = defaultMaxRows
+	}
+
+	var args []interface{}
+	if params, ok := d.Get("parameters").([]interface{}); ok {
+		args = params
+	}
+
+	s := &statements.Select{
+		Query:   q,
+		Args:    args,
+		MaxRows: maxRows,
+	}
+	columns, rows, err := s.Execute(ctx, tx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cols := make([]interface{}, 0, len(columns))
+	for _, c := range columns {
+		cols = append(cols, c)
+	}
+	err = d.Set("columns", cols)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	l := make([]interface{}, 0, len(rows))
+	for _, r := range rows {
+		m := map[string]interface{}{}
+		for k, v := range r {
+			m[k] = v
+		}
+		l = append(l, m)
+	}
+	err = d.Set("rows", l)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash, err := internal.HashUnknown([]interface{}{q}, args)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%x", hash))
+	return nil
+}
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestReadQuery(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"query":      "SELECT ? AS A, CAST(NULL AS VARCHAR(1)) AS B, 42 AS C",
+			"parameters": []interface{}{"foo"},
+			"max_rows":   1,
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	rows := read.Get("rows").([]interface{})
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row: %#v", rows)
+	}
+	row := rows[0].(map[string]interface{})
+	if row["A"] != "foo" || row["C"] != "42" {
+		t.Fatalf("Unexpected row: %#v", row)
+	}
+	if _, ok := row["B"]; ok {
+		t.Fatalf("Expected NULL to be omitted: %#v", row)
+	}
+}
+
+func TestReadQueryMaxRows(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"query":    "SELECT 1 FROM DUAL UNION ALL SELECT 2 FROM DUAL",
+			"max_rows": 1,
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx)
+	if !diags.HasError() {
+		t.Fatal("Expected error for too many rows")
+	}
+}
+
+func TestReadQueryNotSelect(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"query": "DROP SCHEMA foo",
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx)
+	if !diags.HasError() {
+		t.Fatal("Expected error for DROP")
+	}
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
-	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -20,27 +22,36 @@ func Resource() *schema.Resource {
 				Required:    true,
 				Description: "Name of Role",
 			},
+			"comment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Comment of the Role",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp the Role was created at",
+			},
 		},
-		ReadContext: read,
 	}
 }
 
-func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*exaprovider.Client)
+// DataSourceType serves exasol_role with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_role",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
 	return readData(ctx, d, locked.Tx)
 }
 
 func readData(ctx context.Context, d internal.Data, tx *sql.Tx) diag.Diagnostics {
-	name, err := argument.Name(d)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	_, err = tx.QueryContext(ctx, "SELECT ROLE_NAME FROM SYS.EXA_ALL_ROLES WHERE ROLE_NAME = ?", name)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	err := computed.ReadRole(ctx, d, tx)
 	return diag.FromErr(err)
 }
@@ -0,0 +1,71 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestReadRole(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+
+	_, err := locked.Tx.Exec(fmt.Sprintf("CREATE ROLE %s", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = locked.Tx.Exec(fmt.Sprintf("COMMENT ON ROLE %s IS 'Foo Role'", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name": name,
+		},
+	}
+
+	diags := readData(context.TODO(), read, locked.Tx)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	comment, _ := read.Get("comment").(string)
+	if comment != "Foo Role" {
+		t.Fatalf("Expected comment Foo Role: %s", comment)
+	}
+
+	created, _ := read.Get("created").(string)
+	if created == "" {
+		t.Fatal("Expected created to be set")
+	}
+
+	readID := read.Id()
+	if readID != strings.ToUpper(name) {
+		t.Fatalf("Expected name %s: %s", strings.ToUpper(name), readID)
+	}
+}
+
+func TestReadRoleNotFound(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name": fmt.Sprintf("%s_%s", t.Name(), nameSuffix),
+		},
+	}
+
+	diags := readData(context.TODO(), read, locked.Tx)
+	if !diags.HasError() {
+		t.Fatal("Expected error for missing Role")
+	}
+}
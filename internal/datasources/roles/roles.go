@@ -0,0 +1,126 @@
+package roles
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource returns the Datasource listing Exasol Roles
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list Roles with a name matching the regular expression",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of all matching Roles",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All matching Roles",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// DataSourceType serves exasol_roles with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_roles",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	return readData(ctx, d, locked.Tx)
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) diag.Diagnostics {
+	nameRegex, err := argument.NameRegex(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := tx.QueryContext(ctx, "SELECT ROLE_NAME, ROLE_COMMENT, CREATED FROM SYS.EXA_DBA_ROLES ORDER BY ROLE_NAME")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Close()
+
+	names := []interface{}{}
+	roles := []interface{}{}
+	for res.Next() {
+		var name string
+		var comment, created sql.NullString
+		err = res.Scan(&name, &comment, &created)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if !argument.MatchOptional(nameRegex, name) {
+			continue
+		}
+
+		names = append(names, name)
+		roles = append(roles, map[string]interface{}{
+			"name":    name,
+			"comment": comment.String,
+			"created": created.String,
+		})
+	}
+	err = res.Err()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("names", names)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("roles", roles)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, _ := d.Get("name_regex").(string)
+	d.SetId(resource.NewListID("roles", filter))
+	return nil
+}
@@ -0,0 +1,57 @@
+package roles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestReadRoles(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+	prefix := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	for _, suffix := range []string{"A", "B"} {
+		_, err := locked.Tx.Exec(fmt.Sprintf("CREATE ROLE %s_%s", prefix, suffix))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name_regex": "^" + prefix + "_A$",
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	names := read.Get("names").([]interface{})
+	if len(names) != 1 || names[0] != prefix+"_A" {
+		t.Fatalf("Unexpected Roles: %#v", names)
+	}
+}
+
+func TestReadRolesInvalidRegex(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name_regex": "(",
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx)
+	if !diags.HasError() {
+		t.Fatal("Expected error for invalid name_regex")
+	}
+}
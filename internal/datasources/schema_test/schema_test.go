@@ -17,8 +17,8 @@ func TestAccExasolSchema_basic(t *testing.T) {
 	ps := test.NewDefaultAccProviders()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`%s
@@ -0,0 +1,27 @@
+package schemas
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+)
+
+var (
+	exaClient  *exaprovider.Client
+	nameSuffix = acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(testRun(m))
+}
+
+func testRun(m *testing.M) int {
+	exaClient = testclient.MustCreateTestClient()
+
+	return m.Run()
+}
@@ -0,0 +1,157 @@
+package schemas
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	typePhysical = "physical"
+	typeVirtual  = "virtual"
+)
+
+// Resource returns the Datasource listing Exasol Schemas
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list Schemas with a name matching the regular expression",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list Schemas owned by this User or Role",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list physical or virtual Schemas. Lists both if unset.",
+				ValidateFunc: validation.StringInSlice([]string{typePhysical, typeVirtual}, false),
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of all matching Schemas",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All matching Schemas",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"virtual": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// DataSourceType serves exasol_schemas with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_schemas",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	return readData(ctx, d, locked.Tx)
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) diag.Diagnostics {
+	nameRegex, err := argument.NameRegex(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	owner, _ := d.Get("owner").(string)
+	schemaType, _ := d.Get("type").(string)
+
+	res, err := tx.QueryContext(ctx, "SELECT SCHEMA_NAME, SCHEMA_OWNER, SCHEMA_IS_VIRTUAL, SCHEMA_COMMENT FROM SYS.EXA_SCHEMAS ORDER BY SCHEMA_NAME")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Close()
+
+	names := []interface{}{}
+	schemas := []interface{}{}
+	for res.Next() {
+		var name string
+		var schemaOwner, comment sql.NullString
+		var virtual bool
+		err = res.Scan(&name, &schemaOwner, &virtual, &comment)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if !argument.MatchOptional(nameRegex, name) {
+			continue
+		}
+		if owner != "" && !strings.EqualFold(owner, schemaOwner.String) {
+			continue
+		}
+		if schemaType == typePhysical && virtual || schemaType == typeVirtual && !virtual {
+			continue
+		}
+
+		names = append(names, name)
+		schemas = append(schemas, map[string]interface{}{
+			"name":    name,
+			"owner":   schemaOwner.String,
+			"virtual": virtual,
+			"comment": comment.String,
+		})
+	}
+	err = res.Err()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("names", names)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("schemas", schemas)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, _ := d.Get("name_regex").(string)
+	d.SetId(resource.NewListID("schemas", filter, strings.ToUpper(owner), schemaType))
+	return nil
+}
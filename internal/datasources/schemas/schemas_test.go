@@ -0,0 +1,59 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestReadSchemas(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+	name := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	_, err := locked.Tx.Exec(fmt.Sprintf("CREATE SCHEMA %s", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name_regex": "^" + name + "$",
+			"type":       "physical",
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	schemas := read.Get("schemas").([]interface{})
+	if len(schemas) != 1 {
+		t.Fatalf("Expected 1 Schema: %#v", schemas)
+	}
+	s := schemas[0].(map[string]interface{})
+	if s["name"] != name || s["virtual"] != false {
+		t.Fatalf("Unexpected Schema: %#v", s)
+	}
+
+	read = &internal.TestData{
+		Values: map[string]interface{}{
+			"name_regex": "^" + name + "$",
+			"type":       "virtual",
+		},
+	}
+	diags = readData(context.TODO(), read, locked.Tx)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	names := read.Get("names").([]interface{})
+	if len(names) != 0 {
+		t.Fatalf("Expected no virtual Schemas: %#v", names)
+	}
+}
@@ -289,8 +289,8 @@ data "exasol_table" "%s" {
 	ps := test.NewDefaultAccProviders()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: testDefs[0].ObjectTest.Config,
@@ -304,8 +304,8 @@ data "exasol_table" "%s" {
 	})
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: testDefs[1].ObjectTest.Config,
@@ -319,8 +319,8 @@ data "exasol_table" "%s" {
 	})
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: testDefs[2].ObjectTest.Config,
@@ -334,8 +334,8 @@ data "exasol_table" "%s" {
 	})
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: testDefs[3].ObjectTest.Config,
@@ -349,8 +349,8 @@ data "exasol_table" "%s" {
 	})
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: testDefs[4].ObjectTest.Config,
@@ -364,8 +364,8 @@ data "exasol_table" "%s" {
 	})
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: testDefs[5].ObjectTest.Config,
@@ -379,8 +379,8 @@ data "exasol_table" "%s" {
 	})
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: testDefs[6].ObjectTest.Config,
@@ -394,8 +394,8 @@ data "exasol_table" "%s" {
 	})
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: testDefs[7].ObjectTest.Config,
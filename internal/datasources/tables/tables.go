@@ -0,0 +1,152 @@
+package tables
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Resource returns the Datasource listing Tables of a Schema
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"schema": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Schema to list Tables of. Defaults to default_schema of the provider.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list Tables with a name matching the regular expression",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"comment_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list Tables with a comment matching the regular expression",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of all matching Tables",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tables": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All matching Tables",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"row_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"columns": computed.ColumnsSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// DataSourceType serves exasol_tables with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_tables",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	return readData(ctx, d, locked.Tx, c.Defaults)
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx, defaults argument.Defaults) diag.Diagnostics {
+	schemaName, _ := d.Get("schema").(string)
+	if schemaName == "" {
+		schemaName = defaults.Schema
+	}
+	if schemaName == "" {
+		return diag.FromErr(errors.New("schema is required when the provider has no default_schema"))
+	}
+
+	nameRegex, err := argument.NameRegex(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	commentRegex, err := argument.Regex(d, "comment_regex")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := computed.ReadTables(ctx, tx, schemaName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := []interface{}{}
+	tables := []interface{}{}
+	for _, o := range objects {
+		if !argument.MatchOptional(nameRegex, o.Name) || !argument.MatchOptional(commentRegex, o.Comment) {
+			continue
+		}
+		names = append(names, o.Name)
+		tables = append(tables, map[string]interface{}{
+			"name":      o.Name,
+			"owner":     o.Owner,
+			"comment":   o.Comment,
+			"row_count": o.RowCount,
+			"columns":   o.Columns,
+		})
+	}
+
+	err = d.Set("schema", schemaName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("names", names)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("tables", tables)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nameFilter, _ := d.Get("name_regex").(string)
+	commentFilter, _ := d.Get("comment_regex").(string)
+	d.SetId(resource.NewListID("tables", strings.ToUpper(schemaName), nameFilter, commentFilter))
+	return nil
+}
@@ -0,0 +1,76 @@
+package tables
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+)
+
+func TestReadTables(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+	prefix := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	_, err := locked.Tx.Exec(fmt.Sprintf("CREATE TABLE %s.%s_A (A VARCHAR(20) COMMENT IS 'first', B DECIMAL(18,0)) COMMENT IS 'keep'", schemaName, prefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = locked.Tx.Exec(fmt.Sprintf("CREATE TABLE %s.%s_B (A VARCHAR(20)) COMMENT IS 'skip'", schemaName, prefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name_regex":    "^" + prefix,
+			"comment_regex": "^keep$",
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx, argument.Defaults{
+		Schema: schemaName,
+	})
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	tables := read.Get("tables").([]interface{})
+	if len(tables) != 1 {
+		t.Fatalf("Expected 1 Table: %#v", tables)
+	}
+	table := tables[0].(map[string]interface{})
+	if table["name"] != prefix+"_A" {
+		t.Fatalf("Unexpected Table: %#v", table)
+	}
+	columns := table["columns"].([]interface{})
+	if len(columns) != 2 {
+		t.Fatalf("Expected 2 Columns: %#v", columns)
+	}
+	first := columns[0].(map[string]interface{})
+	if first["name"] != "A" || first["comment"] != "first" {
+		t.Fatalf("Unexpected first Column: %#v", first)
+	}
+	if read.Get("schema") != schemaName {
+		t.Fatalf("Unexpected schema: %#v", read.Get("schema"))
+	}
+}
+
+func TestReadTablesWithoutSchema(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{},
+	}
+	diags := readData(context.TODO(), read, locked.Tx, argument.Defaults{})
+	if !diags.HasError() {
+		t.Fatal("Expected error without schema")
+	}
+}
@@ -0,0 +1,186 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	authPassword = "password"
+	authLDAP     = "ldap"
+	authKerberos = "kerberos"
+	authOpenID   = "openid"
+)
+
+// Resource returns the Datasource listing Exasol Users
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list Users with a name matching the regular expression",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"authentication": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list Users authenticating via password, ldap, kerberos or openid",
+				ValidateFunc: validation.StringInSlice([]string{authPassword, authLDAP, authKerberos, authOpenID}, false),
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of all matching Users",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All matching Users",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"authentication": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ldap": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kerberos": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"openid_subject": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Empty before Exasol 7.1",
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// DataSourceType serves exasol_users with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_users",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	version, err := c.Version(ctx, locked.Tx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return readData(ctx, d, locked.Tx, version)
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx, version db.Version) diag.Diagnostics {
+	nameRegex, err := argument.NameRegex(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	auth, _ := d.Get("authentication").(string)
+
+	openIDColumn := "NULL"
+	if version.AtLeast(7, 1) {
+		openIDColumn = "OPENID_SUBJECT"
+	}
+
+	stmt := fmt.Sprintf("SELECT USER_NAME, DISTINGUISHED_NAME, KERBEROS_PRINCIPAL, %s, USER_COMMENT, CREATED FROM SYS.EXA_DBA_USERS ORDER BY USER_NAME", openIDColumn)
+	res, err := tx.QueryContext(ctx, stmt)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Close()
+
+	names := []interface{}{}
+	users := []interface{}{}
+	for res.Next() {
+		var name string
+		var ldap, kerberos, openID, comment, created sql.NullString
+		err = res.Scan(&name, &ldap, &kerberos, &openID, &comment, &created)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		userAuth := authPassword
+		if ldap.Valid {
+			userAuth = authLDAP
+		} else if kerberos.Valid {
+			userAuth = authKerberos
+		} else if openID.Valid {
+			userAuth = authOpenID
+		}
+
+		if !argument.MatchOptional(nameRegex, name) {
+			continue
+		}
+		if auth != "" && auth != userAuth {
+			continue
+		}
+
+		names = append(names, name)
+		users = append(users, map[string]interface{}{
+			"name":           name,
+			"authentication": userAuth,
+			"ldap":           ldap.String,
+			"kerberos":       kerberos.String,
+			"openid_subject": openID.String,
+			"comment":        comment.String,
+			"created":        created.String,
+		})
+	}
+	err = res.Err()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("names", names)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("users", users)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, _ := d.Get("name_regex").(string)
+	d.SetId(resource.NewListID("users", filter, strings.ToLower(auth)))
+	return nil
+}
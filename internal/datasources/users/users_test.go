@@ -0,0 +1,76 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestReadUsers(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+	prefix := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	version, err := exaClient.Version(context.TODO(), locked.Tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = locked.Tx.Exec(fmt.Sprintf(`CREATE USER %s_PWD IDENTIFIED BY "Secret123"`, prefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = locked.Tx.Exec(fmt.Sprintf(`CREATE USER %s_LDAP IDENTIFIED AT LDAP AS 'cn=foo,dc=example,dc=com'`, prefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name_regex": "^" + prefix,
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx, version)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	names := read.Get("names").([]interface{})
+	if len(names) != 2 {
+		t.Fatalf("Expected 2 Users: %#v", names)
+	}
+
+	read = &internal.TestData{
+		Values: map[string]interface{}{
+			"name_regex":     "^" + prefix,
+			"authentication": "ldap",
+		},
+	}
+	diags = readData(context.TODO(), read, locked.Tx, version)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	users := read.Get("users").([]interface{})
+	if len(users) != 1 {
+		t.Fatalf("Expected 1 LDAP User: %#v", users)
+	}
+	user := users[0].(map[string]interface{})
+	if user["name"] != prefix+"_LDAP" {
+		t.Fatalf("Unexpected User: %#v", user)
+	}
+	if user["ldap"] != "cn=foo,dc=example,dc=com" {
+		t.Fatalf("Unexpected ldap: %#v", user["ldap"])
+	}
+	if user["openid_subject"] != "" {
+		t.Fatalf("Unexpected openid_subject: %#v", user["openid_subject"])
+	}
+	if read.Id() == "" {
+		t.Fatal("Expected id to be set")
+	}
+}
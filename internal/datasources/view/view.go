@@ -6,9 +6,11 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -23,8 +25,9 @@ func Resource() *schema.Resource {
 			},
 			"schema": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of Schema that View is in",
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of Schema that View is in. Defaults to default_schema of the provider.",
 			},
 			"column": {
 				Type:        schema.TypeString,
@@ -42,15 +45,25 @@ func Resource() *schema.Resource {
 				Description: "Comment of the View",
 			},
 		},
-		ReadContext: read,
 	}
 }
 
-func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*exaprovider.Client)
+// DataSourceType serves exasol_view with terraform-plugin-framework
+func DataSourceType() tfsdk.DataSourceType {
+	return framework.DataSourceType{
+		Subsystem: "data_view",
+		Schema:    Resource().Schema,
+		Read:      read,
+	}
+}
+
+func read(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ra, diags := argument.ExtractRequiredArguments(d)
+	// Objects named explicitly are looked up without object_prefix
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, argument.Defaults{
+		Schema: c.Defaults.Schema,
+	})
 	if diags.HasError() {
 		return diags
 	}
@@ -79,6 +92,11 @@ func readData(ctx context.Context, d internal.Data, tx *sql.Tx, args argument.Re
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("schema", args.Schema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(resource.NewID(args.Schema, args.Name))
 	return nil
 
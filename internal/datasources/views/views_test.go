@@ -0,0 +1,44 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+)
+
+func TestReadViews(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+	prefix := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	_, err := locked.Tx.Exec(fmt.Sprintf("CREATE VIEW %s.%s_A AS SELECT 1 AS A, 2 AS B COMMENT IS 'keep'", schemaName, prefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"schema":     schemaName,
+			"name_regex": "^" + prefix,
+		},
+	}
+	diags := readData(context.TODO(), read, locked.Tx, argument.Defaults{})
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	names := read.Get("names").([]interface{})
+	if len(names) != 1 || names[0] != prefix+"_A" {
+		t.Fatalf("Unexpected Views: %#v", names)
+	}
+	view := read.Get("views").([]interface{})[0].(map[string]interface{})
+	if view["comment"] != "keep" {
+		t.Fatalf("Unexpected comment: %#v", view["comment"])
+	}
+}
@@ -5,24 +5,41 @@ import (
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"database/sql"
 
+	"github.com/abergmeier/terraform-provider-exasol/internal/logging"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
 	"github.com/exasol/exasol-driver-go"
 )
 
 // Client implements everything that is needed to act as a Provider
 // including the actual client to Exasol Websocket
 type Client struct {
-	conf *exasol.DSNConfig
+	conf     *exasol.DSNConfig
+	Defaults argument.Defaults
+	Session  Session
+
+	versionMu sync.Mutex
+	version   *db.Version
 }
 
 type Locked struct {
 	Conf *exasol.DSNConfig
 	Tx   *sql.Tx
+	db   *sql.DB
+	ctx  context.Context
 }
 
+var (
+	// txCounter identifies the Transactions of Locks in logs
+	txCounter uint64
+)
+
 func NewClient(conf *exasol.DSNConfig) *Client {
 	if conf.ClientName == "" {
 		conf.ClientName = "Terraform"
@@ -43,33 +60,107 @@ func NewClient(conf *exasol.DSNConfig) *Client {
 	return c
 }
 
+// Conf returns the connection configuration of the Client
+func (c *Client) Conf() *exasol.DSNConfig {
+	return c.conf
+}
+
 func (c *Client) Lock(ctx context.Context) *Locked {
-	// All internal logic is based on transactions and
-	// rolling back changes when these fail so disable
-	// autocommit
-	*c.conf.Autocommit = false
-	db, err := sql.Open("exasol", c.conf.ToDSN())
+	connector, err := exasol.ExasolDriver{}.OpenConnector(c.dsn())
 	if err != nil {
 		panic(err)
 	}
+	db := sql.OpenDB(&tracingConnector{
+		connector: connector,
+		ctx:       ctx,
+		txID:      atomic.AddUint64(&txCounter, 1),
+	})
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
+		db.Close()
 		panic(err)
 	}
+	stmts, err := c.Session.Statements()
+	if err != nil {
+		tx.Rollback()
+		db.Close()
+		panic(err)
+	}
+	for _, stmt := range stmts {
+		_, err = tx.ExecContext(ctx, stmt)
+		if err != nil {
+			tx.Rollback()
+			db.Close()
+			panic(fmt.Errorf("applying session setting failed: %w", err))
+		}
+	}
 	return &Locked{
 		Conf: c.conf,
 		Tx:   tx,
+		db:   db,
+		ctx:  ctx,
 	}
 }
 
+// dsn renders the configuration without altering the shared
+// DSNConfig
+func (c *Client) dsn() string {
+	conf := *c.conf
+	// All internal logic is based on transactions and
+	// rolling back changes when these fail so disable
+	// autocommit
+	autocommit := false
+	conf.Autocommit = &autocommit
+	dsn := conf.ToDSN()
+	// ToDSN does not render all settings
+	if conf.ResultSetMaxRows != 0 {
+		dsn += fmt.Sprintf(";resultsetmaxrows=%d", conf.ResultSetMaxRows)
+	}
+	return dsn
+}
+
+// ArgumentDefaults returns the Defaults of the Client passed as meta
+func ArgumentDefaults(meta interface{}) argument.Defaults {
+	return meta.(*Client).Defaults
+}
+
+// Version returns the version of the database for gating features.
+// It is only read once per Client.
+func (c *Client) Version(ctx context.Context, tx *sql.Tx) (db.Version, error) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	if c.version != nil {
+		return *c.version, nil
+	}
+	v, err := db.ReadVersion(ctx, tx)
+	if err != nil {
+		return db.Version{}, err
+	}
+	c.version = &v
+	return v, nil
+}
+
 func (l *Locked) Unlock() {
 	// Ensure that only explicitly committed operations stay
 	err := l.Tx.Rollback()
 	if err != nil && !errors.Is(err, sql.ErrTxDone) {
-		fmt.Println("Rollback failed:", err)
+		logging.Warn(l.ctx, "Rollback failed", map[string]interface{}{
+			"error": err.Error(),
+		})
 	}
 
 	l.Tx = nil
+
+	// Every Lock opens its own pool
+	if l.db != nil {
+		err = l.db.Close()
+		if err != nil {
+			logging.Warn(l.ctx, "Closing connection failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		l.db = nil
+	}
 }
 
 func TestLock(t *testing.T, c *Client) *Locked {
@@ -0,0 +1,133 @@
+package exaprovider
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Same expression the Exasol driver uses to expand host ranges
+	hostRangeExp = regexp.MustCompile(`^((.+?)(\d+))\.\.(\d+)$`)
+)
+
+// Hosts is the resolved host part of a connection to an Exasol cluster
+type Hosts struct {
+	// Host is the comma separated list of hosts and host ranges
+	// as understood by the driver
+	Host string
+	// Port is the port given as part of the hosts or 0 if none
+	// was given
+	Port int
+}
+
+// ParseHosts validates hosts and host ranges like 10.0.0.11..14:8563
+// and combines them into a single Host string for the driver.
+// Every entry may itself be a comma separated list. IPv6 addresses
+// have to be enclosed in brackets like [fd00::11]:8563 and keep
+// their brackets in Host.
+func ParseHosts(entries ...string) (Hosts, error) {
+	hs := Hosts{}
+	var parts []string
+
+	for _, entry := range entries {
+		for _, part := range strings.Split(entry, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				return Hosts{}, fmt.Errorf("empty host in %q", entry)
+			}
+
+			host, port, err := splitHostPort(part)
+			if err != nil {
+				return Hosts{}, err
+			}
+			if port != 0 {
+				if hs.Port != 0 && hs.Port != port {
+					return Hosts{}, fmt.Errorf("conflicting ports %d and %d in hosts", hs.Port, port)
+				}
+				hs.Port = port
+			}
+
+			err = validateHost(host)
+			if err != nil {
+				return Hosts{}, err
+			}
+			parts = append(parts, host)
+		}
+	}
+
+	if len(parts) == 0 {
+		return Hosts{}, nil
+	}
+
+	hs.Host = strings.Join(parts, ",")
+	return hs, nil
+}
+
+func splitHostPort(part string) (string, int, error) {
+	if strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]") {
+		return part, 0, nil
+	}
+	if !strings.HasPrefix(part, "[") && strings.Count(part, ":") > 1 {
+		return "", 0, fmt.Errorf("IPv6 address in host %q has to be enclosed in brackets", part)
+	}
+	if !strings.Contains(part, ":") {
+		return part, 0, nil
+	}
+	host, p, err := net.SplitHostPort(part)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid host %q: %w", part, err)
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port in host %q", part)
+	}
+	if strings.HasPrefix(part, "[") {
+		host = "[" + host + "]"
+	}
+	return host, port, nil
+}
+
+// IsIPv6 reports whether host is a bracketed IPv6 address
+func IsIPv6(host string) bool {
+	return strings.HasPrefix(host, "[")
+}
+
+func validateHost(host string) error {
+	if strings.ContainsAny(host, " \t;") {
+		return fmt.Errorf("invalid character in host %q", host)
+	}
+	if IsIPv6(host) {
+		ip := net.ParseIP(strings.Trim(host, "[]"))
+		if ip == nil || ip.To4() != nil || !strings.HasSuffix(host, "]") {
+			return fmt.Errorf("invalid IPv6 address %q", host)
+		}
+		return nil
+	}
+	if !strings.Contains(host, "..") {
+		return nil
+	}
+	m := hostRangeExp.FindStringSubmatch(host)
+	if m == nil {
+		return fmt.Errorf("invalid host range %q: expected <prefix><start>..<stop>", host)
+	}
+	_, _, err := rangeLimits(host, m)
+	return err
+}
+
+func rangeLimits(host string, m []string) (int, int, error) {
+	start, err := strconv.Atoi(m[3])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start of host range %q: %w", host, err)
+	}
+	stop, err := strconv.Atoi(m[4])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid stop of host range %q: %w", host, err)
+	}
+	if stop < start {
+		return 0, 0, fmt.Errorf("invalid host range %q: %d is lower than %d", host, stop, start)
+	}
+	return start, stop, nil
+}
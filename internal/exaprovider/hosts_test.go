@@ -0,0 +1,66 @@
+package exaprovider
+
+import "testing"
+
+func TestParseHosts(t *testing.T) {
+	t.Parallel()
+
+	hs, err := ParseHosts("10.0.0.11..14:8563")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if hs.Host != "10.0.0.11..14" {
+		t.Fatalf("Unexpected host: %s", hs.Host)
+	}
+	if hs.Port != 8563 {
+		t.Fatalf("Unexpected port: %d", hs.Port)
+	}
+
+	hs, err = ParseHosts("exa1,exa2", "exanode1..3")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if hs.Host != "exa1,exa2,exanode1..3" {
+		t.Fatalf("Unexpected host: %s", hs.Host)
+	}
+	if hs.Port != 0 {
+		t.Fatalf("Unexpected port: %d", hs.Port)
+	}
+
+	hs, err = ParseHosts("[fd00::11]:8563,[fd00::12]")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if hs.Host != "[fd00::11],[fd00::12]" {
+		t.Fatalf("Unexpected host: %s", hs.Host)
+	}
+	if hs.Port != 8563 {
+		t.Fatalf("Unexpected port: %d", hs.Port)
+	}
+}
+
+func TestParseHostsInvalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		"10.0.0.14..11",
+		"10.0.0.11..",
+		"..14",
+		"exa1,,exa2",
+		"exa1:foo",
+		"exa1:8563,exa2:8564",
+		"exa 1",
+		"fd00::11",
+		"[fd00::11",
+		"[fd00::11]:foo",
+		"[10.0.0.11]",
+		"[exa1]:8563",
+	}
+
+	for _, host := range invalid {
+		_, err := ParseHosts(host)
+		if err == nil {
+			t.Errorf("Expected error for %s", host)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package exaprovider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+)
+
+var (
+	sessionParameterExp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Session holds settings that are applied to every Session
+// right after the Transaction opened
+type Session struct {
+	// QueryTimeout in seconds. 0 keeps the database default.
+	QueryTimeout int
+	// Parameters are set via ALTER SESSION SET <key> = <value>
+	Parameters map[string]string
+}
+
+// Validate checks that all settings can be rendered into
+// valid statements
+func (s Session) Validate() error {
+	if s.QueryTimeout < 0 {
+		return fmt.Errorf("negative query timeout %d", s.QueryTimeout)
+	}
+	for k := range s.Parameters {
+		if !sessionParameterExp.MatchString(k) {
+			return fmt.Errorf("invalid session parameter name %q", k)
+		}
+		if strings.EqualFold(k, "QUERY_TIMEOUT") && s.QueryTimeout != 0 {
+			return fmt.Errorf("session parameter %s conflicts with query timeout", k)
+		}
+	}
+	return nil
+}
+
+// Statements renders the ALTER SESSION statements in a stable order
+func (s Session) Statements() ([]string, error) {
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var stmts []string
+	if s.QueryTimeout != 0 {
+		stmts = append(stmts, fmt.Sprintf("ALTER SESSION SET QUERY_TIMEOUT = %d", s.QueryTimeout))
+	}
+
+	keys := make([]string, 0, len(s.Parameters))
+	for k := range s.Parameters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		stmts = append(stmts, fmt.Sprintf("ALTER SESSION SET %s = %s", strings.ToUpper(k), db.ParameterValue(s.Parameters[k])))
+	}
+	return stmts, nil
+}
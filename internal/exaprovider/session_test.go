@@ -0,0 +1,89 @@
+package exaprovider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSessionStatements(t *testing.T) {
+	t.Parallel()
+
+	s := Session{
+		QueryTimeout: 300,
+		Parameters: map[string]string{
+			"time_zone":       "EUROPE/BERLIN",
+			"NLS_DATE_FORMAT": "YYYY-MM-DD",
+			"NLS_FIRST_DAY":   "7",
+			"NLS_DATE_LANG":   "ENG'",
+		},
+	}
+
+	stmts, err := s.Statements()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	expected := []string{
+		"ALTER SESSION SET QUERY_TIMEOUT = 300",
+		"ALTER SESSION SET NLS_DATE_FORMAT = 'YYYY-MM-DD'",
+		"ALTER SESSION SET NLS_DATE_LANG = 'ENG'''",
+		"ALTER SESSION SET NLS_FIRST_DAY = 7",
+		"ALTER SESSION SET TIME_ZONE = 'EUROPE/BERLIN'",
+	}
+	d := cmp.Diff(expected, stmts)
+	if d != "" {
+		t.Fatal("Unexpected statements:", d)
+	}
+}
+
+func TestSessionInvalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []Session{
+		{QueryTimeout: -1},
+		{Parameters: map[string]string{"TIME_ZONE = 'UTC'; DROP": "x"}},
+		{QueryTimeout: 1, Parameters: map[string]string{"query_timeout": "2"}},
+	}
+	for _, s := range invalid {
+		_, err := s.Statements()
+		if err == nil {
+			t.Errorf("Expected error for %#v", s)
+		}
+	}
+}
+
+func TestClientDSN(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient(&exasol.DSNConfig{
+		Host:             "localhost",
+		Port:             8563,
+		ResultSetMaxRows: 1000,
+		FetchSize:        2000,
+	})
+
+	dsn := c.dsn()
+	if c.Conf().Autocommit != nil {
+		t.Fatal("Unexpected change of shared configuration")
+	}
+
+	conf, err := exasol.ParseDSN(dsn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if *conf.Autocommit {
+		t.Errorf("Expected autocommit to be disabled: %s", dsn)
+	}
+	if conf.ResultSetMaxRows != 1000 {
+		t.Errorf("Unexpected resultsetmaxrows: %s", dsn)
+	}
+	if conf.FetchSize != 2000 {
+		t.Errorf("Unexpected fetchsize: %s", dsn)
+	}
+	if !strings.HasPrefix(dsn, "exa:localhost:8563;") {
+		t.Errorf("Unexpected dsn: %s", dsn)
+	}
+}
@@ -0,0 +1,245 @@
+package exaprovider
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/logging"
+)
+
+var (
+	secretExp = regexp.MustCompile(`(?i)\b(IDENTIFIED\s+BY|REPLACE)\s+("(?:[^"]|"")*"|'(?:[^']|'')*')`)
+	// userInfoExp matches the password of URLs e.g. in TO of Connections
+	userInfoExp = regexp.MustCompile(`(://[^/:@'"\s]*:)[^/@'"\s]+@`)
+	// paramExp matches secret parameters e.g. of JDBC URLs
+	paramExp = regexp.MustCompile(`(?i)\b(password|pwd|secret|token|sig)=[^;&'"\s]*`)
+)
+
+type redactKey struct{}
+
+// WithRedactedStatements returns a Context whose statements are traced
+// without their text. Used for SQL that is supplied by users and may
+// therefore contain arbitrary secrets.
+func WithRedactedStatements(ctx context.Context) context.Context {
+	return context.WithValue(ctx, redactKey{}, true)
+}
+
+func isRedacted(ctx context.Context) bool {
+	redacted, _ := ctx.Value(redactKey{}).(bool)
+	return redacted
+}
+
+// RedactSecrets removes passwords from statements so that
+// they can be logged
+func RedactSecrets(stmt string) string {
+	stmt = secretExp.ReplaceAllString(stmt, "$1 '***'")
+	stmt = userInfoExp.ReplaceAllString(stmt, "$1***@")
+	return paramExp.ReplaceAllString(stmt, "$1=***")
+}
+
+// tracingConnector logs every statement of a Lock
+type tracingConnector struct {
+	connector driver.Connector
+	// ctx carries the logger of the Lock. It is not used for
+	// cancellation
+	ctx  context.Context
+	txID uint64
+}
+
+type tracingConn struct {
+	driver.Conn
+	c *tracingConnector
+}
+
+type tracingTx struct {
+	driver.Tx
+	c *tracingConnector
+}
+
+type tracingStmt struct {
+	driver.Stmt
+	c     *tracingConnector
+	ctx   context.Context
+	query string
+}
+
+func (tc *tracingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := tc.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracingConn{
+		Conn: conn,
+		c:    tc,
+	}, nil
+}
+
+func (tc *tracingConnector) Driver() driver.Driver {
+	return tc.connector.Driver()
+}
+
+func (tc *tracingConnector) logStatement(ctx context.Context, stmt string, args int, start time.Time, err error) {
+	if isRedacted(ctx) || isRedacted(tc.ctx) {
+		stmt = "<redacted>"
+	}
+	fields := map[string]interface{}{
+		"tx_id":       tc.txID,
+		"statement":   RedactSecrets(stmt),
+		"args":        args,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	logging.Debug(tc.ctx, "Executed SQL statement", fields)
+}
+
+func (c *tracingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := q.QueryContext(ctx, query, args)
+	c.c.logStatement(ctx, query, len(args), start, err)
+	return rows, err
+}
+
+func (c *tracingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	res, err := e.ExecContext(ctx, query, args)
+	c.c.logStatement(ctx, query, len(args), start, err)
+	return res, err
+}
+
+func (c *tracingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = p.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tracingStmt{
+		Stmt:  stmt,
+		c:     c.c,
+		ctx:   ctx,
+		query: query,
+	}, nil
+}
+
+func (c *tracingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *tracingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var tx driver.Tx
+	var err error
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = b.BeginTx(ctx, opts)
+	} else if opts.Isolation != driver.IsolationLevel(0) || opts.ReadOnly {
+		// Same restriction as database/sql applies to drivers
+		// without ConnBeginTx
+		return nil, errors.New("driver does not support non-default isolation level or read-only transactions")
+	} else {
+		tx, err = c.Conn.Begin()
+	}
+	if err != nil {
+		return nil, err
+	}
+	logging.Trace(c.c.ctx, "Began transaction", map[string]interface{}{
+		"tx_id": c.c.txID,
+	})
+	return &tracingTx{
+		Tx: tx,
+		c:  c.c,
+	}, nil
+}
+
+func (s *tracingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var res driver.Result
+	var err error
+	if e, ok := s.Stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		values, err = namedValues(args)
+		if err == nil {
+			res, err = s.Stmt.Exec(values)
+		}
+	}
+	s.c.logStatement(s.statementContext(ctx), s.query, len(args), start, err)
+	return res, err
+}
+
+func (s *tracingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	if q, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		values, err = namedValues(args)
+		if err == nil {
+			rows, err = s.Stmt.Query(values)
+		}
+	}
+	s.c.logStatement(s.statementContext(ctx), s.query, len(args), start, err)
+	return rows, err
+}
+
+// statementContext keeps redaction requested while preparing
+func (s *tracingStmt) statementContext(ctx context.Context) context.Context {
+	if isRedacted(s.ctx) {
+		return s.ctx
+	}
+	return ctx
+}
+
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("driver does not support named parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
+
+func (tx *tracingTx) Commit() error {
+	err := tx.Tx.Commit()
+	logging.Trace(tx.c.ctx, "Committed transaction", map[string]interface{}{
+		"tx_id": tx.c.txID,
+		"error": errString(err),
+	})
+	return err
+}
+
+func (tx *tracingTx) Rollback() error {
+	err := tx.Tx.Rollback()
+	logging.Trace(tx.c.ctx, "Rolled back transaction", map[string]interface{}{
+		"tx_id": tx.c.txID,
+		"error": errString(err),
+	})
+	return err
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
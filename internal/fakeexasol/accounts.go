@@ -0,0 +1,887 @@
+package fakeexasol
+
+import (
+	"strings"
+)
+
+// systemPrivileges lists the privileges accepted by GRANT <priv> TO
+var systemPrivileges = map[string]bool{}
+
+func init() {
+	for _, p := range []string{
+		"GRANT ANY OBJECT PRIVILEGE", "GRANT ANY PRIVILEGE", "GRANT ANY ROLE",
+		"MANAGE CONSUMER GROUPS", "ALTER SYSTEM", "CREATE SESSION", "KILL ANY SESSION",
+		"IMPERSONATE ANY USER", "CREATE USER", "ALTER USER", "DROP USER",
+		"CREATE ROLE", "DROP ANY ROLE", "CREATE CONNECTION", "ALTER ANY CONNECTION",
+		"DROP ANY CONNECTION", "USE ANY CONNECTION", "ACCESS ANY CONNECTION",
+		"CREATE SCHEMA", "ALTER ANY SCHEMA", "DROP ANY SCHEMA",
+		"CREATE VIRTUAL SCHEMA", "ALTER ANY VIRTUAL SCHEMA", "ALTER ANY VIRTUAL SCHEMA REFRESH",
+		"DROP ANY VIRTUAL SCHEMA", "CREATE TABLE", "CREATE ANY TABLE", "ALTER ANY TABLE",
+		"DELETE ANY TABLE", "DROP ANY TABLE", "INSERT ANY TABLE", "SELECT ANY TABLE",
+		"UPDATE ANY TABLE", "CREATE VIEW", "CREATE ANY VIEW", "DROP ANY VIEW",
+		"CREATE FUNCTION", "CREATE ANY FUNCTION", "DROP ANY FUNCTION", "EXECUTE ANY FUNCTION",
+		"CREATE SCRIPT", "CREATE ANY SCRIPT", "DROP ANY SCRIPT", "EXECUTE ANY SCRIPT",
+		"SELECT ANY DICTIONARY", "USE ANY SCHEMA", "EXPORT", "IMPORT",
+	} {
+		systemPrivileges[p] = true
+	}
+}
+
+// objectPrivileges lists the privileges accepted by GRANT <priv> ON
+var objectPrivileges = map[string]bool{
+	"ALTER": true, "SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true,
+	"REFERENCES": true, "EXECUTE": true, "ACCESS": true, "USAGE": true,
+}
+
+func (p *parser) createRole() (mutation, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		if c.isGrantee(name) {
+			return 0, errorf("user or role %s already exists", name)
+		}
+		c.roles[name] = &roleObject{
+			id:      c.newID(),
+			name:    name,
+			created: x.now(),
+		}
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) dropRole() (mutation, error) {
+	ifExists := p.accept("IF", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	p.accept("CASCADE")
+	return func(x *execContext, c *catalog) (int, error) {
+		if _, ok := c.roles[name]; !ok {
+			if ifExists {
+				return 0, nil
+			}
+			return 0, errorf("role %s not found", name)
+		}
+		if name == "PUBLIC" || name == "DBA" {
+			return 0, errorf("role %s cannot be dropped", name)
+		}
+		delete(c.roles, name)
+		c.dropGrantee(name)
+		return 0, nil
+	}, nil
+}
+
+// authentication is the IDENTIFIED clause of CREATE USER and ALTER USER
+type authentication struct {
+	password string
+	ldap     string
+	kerberos string
+	openid   string
+	// replace is the old password of ALTER USER .. REPLACE
+	replace *string
+}
+
+func (p *parser) authentication() (*authentication, error) {
+	a := &authentication{}
+	var err error
+	switch {
+	case p.accept("IDENTIFIED", "BY", "KERBEROS", "PRINCIPAL"):
+		a.kerberos, err = p.stringValue()
+	case p.accept("IDENTIFIED", "AT", "LDAP", "AS"):
+		a.ldap, err = p.stringValue()
+		p.accept("FORCE")
+	case p.accept("IDENTIFIED", "BY", "OPENID", "SUBJECT"):
+		a.openid, err = p.stringValue()
+	case p.accept("IDENTIFIED", "BY"):
+		a.password, err = p.password()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept("REPLACE") {
+			old, err := p.password()
+			if err != nil {
+				return nil, err
+			}
+			a.replace = &old
+		}
+	default:
+		return nil, p.unexpected("IDENTIFIED")
+	}
+	return a, err
+}
+
+// password reads a quoted identifier, string or bind parameter.
+// Unlike identifiers, passwords are case sensitive.
+func (p *parser) password() (string, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenQuotedIdent:
+		p.i++
+		return t.text, nil
+	case tokenIdent:
+		p.i++
+		return p.sql[t.pos:t.end], nil
+	}
+	return p.stringValue()
+}
+
+func (a *authentication) apply(u *userObject, now string) {
+	u.password, u.ldap, u.kerberos, u.openid = a.password, a.ldap, a.kerberos, a.openid
+	if a.password != "" {
+		u.passwordState = "VALID"
+		u.passwordChanged = now
+	} else {
+		u.passwordState = ""
+		u.passwordChanged = ""
+	}
+}
+
+func (p *parser) createUser() (mutation, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	auth, err := p.authentication()
+	if err != nil {
+		return nil, err
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		if c.isGrantee(name) {
+			return 0, errorf("user or role %s already exists", name)
+		}
+		u := &userObject{
+			id:      c.newID(),
+			name:    name,
+			created: x.now(),
+		}
+		auth.apply(u, u.created)
+		c.users[name] = u
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) alterUser() (mutation, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	var change func(x *execContext, c *catalog, u *userObject) error
+	switch {
+	case p.accept("PASSWORD", "EXPIRE"):
+		change = func(x *execContext, c *catalog, u *userObject) error {
+			if u.password == "" {
+				return errorf("user %s has no password", u.name)
+			}
+			u.passwordState = "EXPIRED"
+			return nil
+		}
+	case p.accept("RESET", "FAILED", "LOGIN", "ATTEMPTS"):
+		change = func(x *execContext, c *catalog, u *userObject) error {
+			u.failedLoginAttempts = 0
+			return nil
+		}
+	case p.accept("SECURITY", "POLICY"):
+		policy := ""
+		if !p.accept("OFF") {
+			policy, err = p.stringValue()
+			if err != nil {
+				return nil, err
+			}
+		}
+		change = func(x *execContext, c *catalog, u *userObject) error {
+			u.passwordPolicy = policy
+			return nil
+		}
+	case p.accept("SET", "CONSUMER_GROUP", "="):
+		if _, err := p.name(); err != nil {
+			return nil, err
+		}
+		change = func(x *execContext, c *catalog, u *userObject) error {
+			return nil
+		}
+	default:
+		auth, err := p.authentication()
+		if err != nil {
+			return nil, err
+		}
+		change = func(x *execContext, c *catalog, u *userObject) error {
+			if auth.replace != nil && u.password != *auth.replace {
+				return errorf("old password does not match")
+			}
+			auth.apply(u, x.now())
+			return nil
+		}
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		u, ok := c.users[name]
+		if !ok {
+			return 0, errorf("user %s not found", name)
+		}
+		return 0, change(x, c, u)
+	}, nil
+}
+
+func (p *parser) dropUser() (mutation, error) {
+	ifExists := p.accept("IF", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	cascade := p.accept("CASCADE")
+	return func(x *execContext, c *catalog) (int, error) {
+		if _, ok := c.users[name]; !ok {
+			if ifExists {
+				return 0, nil
+			}
+			return 0, errorf("user %s not found", name)
+		}
+		if name == x.user {
+			return 0, errorf("user %s is currently logged in", name)
+		}
+		for _, s := range c.schemas {
+			if s.owner == name && !cascade {
+				return 0, errorf("user %s owns schema %s - use DROP USER %s CASCADE", name, s.name, name)
+			}
+		}
+		for _, k := range sortedKeys(c.schemas) {
+			if c.schemas[k].owner == name {
+				delete(c.schemas, k)
+				c.dropObjectPrivs(k, "")
+			}
+		}
+		delete(c.users, name)
+		c.dropGrantee(name)
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) createConnection(replace bool) (mutation, error) {
+	return p.createConnectionWith(replace, false)
+}
+
+// createConnectionWith reads the rest of CREATE CONNECTION or ALTER CONNECTION
+func (p *parser) createConnectionWith(replace, alter bool) (mutation, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expect("TO")
+	if err != nil {
+		return nil, err
+	}
+	to, err := p.stringValue()
+	if err != nil {
+		return nil, err
+	}
+	user, password := "", ""
+	if p.accept("USER") {
+		user, err = p.stringValue()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("IDENTIFIED", "BY") {
+		password, err = p.stringValue()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		conn, exists := c.connections[name]
+		switch {
+		case alter && !exists:
+			return 0, errorf("connection %s not found", name)
+		case !alter && exists && !replace:
+			return 0, errorf("connection %s already exists", name)
+		case !exists:
+			conn = &connectionObject{
+				id:      c.newID(),
+				name:    name,
+				created: x.now(),
+			}
+			c.connections[name] = conn
+		}
+		conn.connString = to
+		conn.user = user
+		conn.password = password
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) dropConnection() (mutation, error) {
+	ifExists := p.accept("IF", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		if _, ok := c.connections[name]; !ok {
+			if ifExists {
+				return 0, nil
+			}
+			return 0, errorf("connection %s not found", name)
+		}
+		delete(c.connections, name)
+		connPrivs := c.connPrivs[:0]
+		for _, cp := range c.connPrivs {
+			if cp.connection != name {
+				connPrivs = append(connPrivs, cp)
+			}
+		}
+		c.connPrivs = connPrivs
+		restrictedPrivs := c.restrictedPrivs[:0]
+		for _, rp := range c.restrictedPrivs {
+			if rp.connection != name {
+				restrictedPrivs = append(restrictedPrivs, rp)
+			}
+		}
+		c.restrictedPrivs = restrictedPrivs
+		return 0, nil
+	}, nil
+}
+
+func (c *catalog) renameGrantee(kind, old, new string) error {
+	if c.isGrantee(new) {
+		return errorf("user or role %s already exists", new)
+	}
+	if kind == "USER" {
+		u, ok := c.users[old]
+		if !ok {
+			return errorf("user %s not found", old)
+		}
+		delete(c.users, old)
+		u.name = new
+		c.users[new] = u
+	} else {
+		r, ok := c.roles[old]
+		if !ok {
+			return errorf("role %s not found", old)
+		}
+		delete(c.roles, old)
+		r.name = new
+		c.roles[new] = r
+	}
+	for i := range c.sysPrivs {
+		if c.sysPrivs[i].grantee == old {
+			c.sysPrivs[i].grantee = new
+		}
+	}
+	for i := range c.rolePrivs {
+		if c.rolePrivs[i].grantee == old {
+			c.rolePrivs[i].grantee = new
+		}
+		if c.rolePrivs[i].role == old {
+			c.rolePrivs[i].role = new
+		}
+	}
+	for i := range c.objPrivs {
+		if c.objPrivs[i].grantee == old {
+			c.objPrivs[i].grantee = new
+		}
+	}
+	for i := range c.connPrivs {
+		if c.connPrivs[i].grantee == old {
+			c.connPrivs[i].grantee = new
+		}
+	}
+	for i := range c.restrictedPrivs {
+		if c.restrictedPrivs[i].grantee == old {
+			c.restrictedPrivs[i].grantee = new
+		}
+	}
+	for i := range c.impersonationPrivs {
+		if c.impersonationPrivs[i].grantee == old {
+			c.impersonationPrivs[i].grantee = new
+		}
+		if c.impersonationPrivs[i].on == old {
+			c.impersonationPrivs[i].on = new
+		}
+	}
+	for _, s := range c.schemas {
+		if s.owner == old {
+			s.owner = new
+		}
+	}
+	return nil
+}
+
+func (c *catalog) renameConnection(old, new string) error {
+	conn, ok := c.connections[old]
+	if !ok {
+		return errorf("connection %s not found", old)
+	}
+	if _, ok := c.connections[new]; ok {
+		return errorf("connection %s already exists", new)
+	}
+	delete(c.connections, old)
+	conn.name = new
+	c.connections[new] = conn
+	for i := range c.connPrivs {
+		if c.connPrivs[i].connection == old {
+			c.connPrivs[i].connection = new
+		}
+	}
+	for i := range c.restrictedPrivs {
+		if c.restrictedPrivs[i].connection == old {
+			c.restrictedPrivs[i].connection = new
+		}
+	}
+	return nil
+}
+
+// grantClause is the parsed content shared by GRANT and REVOKE
+type grantClause struct {
+	// privileges are system or object privileges, or names of
+	// Roles and Connections depending on kind
+	privileges []string
+	kind       string
+	object     objectName
+	objectType string
+	forScript  objectName
+	grantees   []string
+	admin      bool
+}
+
+const (
+	grantSystem        = "SYSTEM"
+	grantObject        = "OBJECT"
+	grantRole          = "ROLE"
+	grantConnection    = "CONNECTION"
+	grantRestricted    = "RESTRICTED"
+	grantImpersonation = "IMPERSONATION"
+)
+
+// privilegeList reads privileges or names up to ON, TO or FROM
+func (p *parser) privilegeList() ([]string, error) {
+	list := []string{}
+	for {
+		words := []string{}
+		for {
+			t := p.peek()
+			if t.kind == tokenEOF || t.is(",") || t.is("ON") || t.is("TO") || t.is("FROM") {
+				break
+			}
+			if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+				return nil, p.unexpected("privilege")
+			}
+			words = append(words, t.text)
+			p.i++
+		}
+		if len(words) == 0 {
+			return nil, p.unexpected("privilege")
+		}
+		list = append(list, strings.Join(words, " "))
+		if !p.accept(",") {
+			return list, nil
+		}
+	}
+}
+
+func (p *parser) grantClause(grant bool) (*grantClause, error) {
+	g := &grantClause{}
+	if !grant && p.accept("ADMIN", "OPTION", "FOR") {
+		g.admin = true
+	}
+	if p.accept("IMPERSONATION", "ON") {
+		g.kind = grantImpersonation
+		on, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		g.privileges = []string{on}
+	} else if p.accept("ACCESS", "ON", "CONNECTION") {
+		g.kind = grantRestricted
+		conn, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		g.privileges = []string{conn}
+		err = p.expect("FOR")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.acceptAny("SCRIPT", "SCHEMA"); !ok {
+			return nil, p.unexpected("SCRIPT or SCHEMA")
+		}
+		g.forScript, err = p.objectName()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if p.accept("CONNECTION") {
+			g.kind = grantConnection
+		}
+		var err error
+		g.privileges, err = p.privilegeList()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept("ON") {
+			g.kind = grantObject
+			if t, ok := p.acceptAny("SCHEMA", "TABLE", "VIEW", "FUNCTION", "SCRIPT"); ok {
+				g.objectType = t
+			}
+			g.object, err = p.objectName()
+			if err != nil {
+				return nil, err
+			}
+			for i, priv := range g.privileges {
+				priv = strings.TrimSuffix(priv, " PRIVILEGES")
+				if priv == "ALL" {
+					g.privileges = []string{"ALL"}
+					break
+				}
+				if !objectPrivileges[priv] {
+					return nil, errorf("unknown object privilege %s", priv)
+				}
+				g.privileges[i] = priv
+			}
+		}
+	}
+
+	word := "TO"
+	if !grant {
+		word = "FROM"
+	}
+	err := p.expect(word)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		n, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		g.grantees = append(g.grantees, n)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if grant && p.accept("WITH", "ADMIN", "OPTION") {
+		g.admin = true
+	}
+	if !grant {
+		p.accept("CASCADE", "CONSTRAINTS")
+	}
+	return g, nil
+}
+
+// resolve decides between system privileges, Roles and Connections
+// once the catalog is known
+func (g *grantClause) resolve(c *catalog) (string, error) {
+	if g.kind != "" {
+		return g.kind, nil
+	}
+	if len(g.privileges) == 1 && g.privileges[0] == "ALL PRIVILEGES" || len(g.privileges) == 1 && g.privileges[0] == "ALL" {
+		return grantSystem, nil
+	}
+	kind := ""
+	for _, priv := range g.privileges {
+		k := ""
+		switch {
+		case systemPrivileges[priv]:
+			k = grantSystem
+		case c.roles[priv] != nil:
+			k = grantRole
+		case c.connections[priv] != nil:
+			k = grantConnection
+		default:
+			return "", errorf("privilege, role or connection %s not found", priv)
+		}
+		if kind != "" && kind != k {
+			return "", errorf("cannot mix privileges, roles and connections")
+		}
+		kind = k
+	}
+	return kind, nil
+}
+
+// objectType resolves the type of the object of GRANT .. ON
+func (g *grantClause) target(x *execContext, c *catalog) (objectName, string, error) {
+	if g.objectType == "SCHEMA" || (g.objectType == "" && g.object.schema == "" && c.schemas[g.object.name] != nil) {
+		if _, err := c.schema(g.object.name); err != nil {
+			return objectName{}, "", err
+		}
+		return objectName{name: g.object.name}, "SCHEMA", nil
+	}
+	qn, err := x.qualify(g.object)
+	if err != nil {
+		return objectName{}, "", err
+	}
+	t, _, err := c.object(qn)
+	if err != nil {
+		return objectName{}, "", err
+	}
+	if t != nil {
+		return qn, "TABLE", nil
+	}
+	return qn, "VIEW", nil
+}
+
+func (p *parser) grant() (mutation, error) {
+	g, err := p.grantClause(true)
+	if err != nil {
+		return nil, err
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		for _, grantee := range g.grantees {
+			if !c.isGrantee(grantee) {
+				return 0, errorf("user or role %s not found", grantee)
+			}
+		}
+		kind, err := g.resolve(c)
+		if err != nil {
+			return 0, err
+		}
+		for _, grantee := range g.grantees {
+			err = c.grant(x, g, kind, grantee)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return 0, nil
+	}, nil
+}
+
+func (c *catalog) grant(x *execContext, g *grantClause, kind, grantee string) error {
+	switch kind {
+	case grantSystem:
+		privileges := g.privileges
+		if privileges[0] == "ALL" || privileges[0] == "ALL PRIVILEGES" {
+			privileges = sortedKeys(systemPrivileges)
+		}
+		for _, priv := range privileges {
+			i := c.findSysPriv(grantee, priv)
+			if i == -1 {
+				c.sysPrivs = append(c.sysPrivs, sysPriv{grantee: grantee, privilege: priv, admin: g.admin})
+			} else if g.admin {
+				c.sysPrivs[i].admin = true
+			}
+		}
+	case grantRole:
+		for _, role := range g.privileges {
+			if role == grantee {
+				return errorf("role %s cannot be granted to itself", role)
+			}
+			i := c.findRolePriv(grantee, role)
+			if i == -1 {
+				c.rolePrivs = append(c.rolePrivs, rolePriv{grantee: grantee, role: role, admin: g.admin})
+			} else if g.admin {
+				c.rolePrivs[i].admin = true
+			}
+		}
+	case grantConnection:
+		for _, conn := range g.privileges {
+			if c.connections[conn] == nil {
+				return errorf("connection %s not found", conn)
+			}
+			i := c.findConnPriv(grantee, conn)
+			if i == -1 {
+				c.connPrivs = append(c.connPrivs, connPriv{grantee: grantee, connection: conn, admin: g.admin})
+			} else if g.admin {
+				c.connPrivs[i].admin = true
+			}
+		}
+	case grantObject:
+		target, typ, err := g.target(x, c)
+		if err != nil {
+			return err
+		}
+		privileges := g.privileges
+		if privileges[0] == "ALL" {
+			privileges = []string{"ALTER", "DELETE", "EXECUTE", "INSERT", "REFERENCES", "SELECT", "UPDATE"}
+			if typ == "VIEW" {
+				privileges = []string{"SELECT"}
+			}
+		}
+		for _, priv := range privileges {
+			op := objPriv{schema: target.schema, name: target.name, typ: typ, privilege: priv, grantee: grantee, grantor: x.user}
+			if c.findObjPriv(op) == -1 {
+				c.objPrivs = append(c.objPrivs, op)
+			}
+		}
+	case grantRestricted:
+		conn := g.privileges[0]
+		if c.connections[conn] == nil {
+			return errorf("connection %s not found", conn)
+		}
+		script, err := x.qualify(g.forScript)
+		if err != nil {
+			return err
+		}
+		rp := restrictedPriv{connection: conn, forSchema: script.schema, forName: script.name, grantee: grantee, grantor: x.user}
+		if c.findRestrictedPriv(rp) == -1 {
+			c.restrictedPrivs = append(c.restrictedPrivs, rp)
+		}
+	case grantImpersonation:
+		on := g.privileges[0]
+		if !c.isGrantee(on) {
+			return errorf("user or role %s not found", on)
+		}
+		if c.findImpersonationPriv(grantee, on) == -1 {
+			c.impersonationPrivs = append(c.impersonationPrivs, impersonationPriv{grantee: grantee, on: on, grantor: x.user})
+		}
+	}
+	return nil
+}
+
+func (p *parser) revoke() (mutation, error) {
+	g, err := p.grantClause(false)
+	if err != nil {
+		return nil, err
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		kind, err := g.resolve(c)
+		if err != nil {
+			return 0, err
+		}
+		for _, grantee := range g.grantees {
+			err = c.revoke(x, g, kind, grantee)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return 0, nil
+	}, nil
+}
+
+func (c *catalog) revoke(x *execContext, g *grantClause, kind, grantee string) error {
+	switch kind {
+	case grantSystem:
+		privileges := g.privileges
+		if privileges[0] == "ALL" || privileges[0] == "ALL PRIVILEGES" {
+			privileges = sortedKeys(systemPrivileges)
+		}
+		for _, priv := range privileges {
+			i := c.findSysPriv(grantee, priv)
+			if i == -1 {
+				if len(privileges) > 1 {
+					continue
+				}
+				return errorf("%s has not been granted to %s", priv, grantee)
+			}
+			if g.admin {
+				c.sysPrivs[i].admin = false
+				continue
+			}
+			c.sysPrivs = append(c.sysPrivs[:i], c.sysPrivs[i+1:]...)
+		}
+	case grantRole:
+		for _, role := range g.privileges {
+			i := c.findRolePriv(grantee, role)
+			if i == -1 {
+				return errorf("role %s has not been granted to %s", role, grantee)
+			}
+			if g.admin {
+				c.rolePrivs[i].admin = false
+				continue
+			}
+			c.rolePrivs = append(c.rolePrivs[:i], c.rolePrivs[i+1:]...)
+		}
+	case grantConnection:
+		for _, conn := range g.privileges {
+			i := c.findConnPriv(grantee, conn)
+			if i == -1 {
+				return errorf("connection %s has not been granted to %s", conn, grantee)
+			}
+			if g.admin {
+				c.connPrivs[i].admin = false
+				continue
+			}
+			c.connPrivs = append(c.connPrivs[:i], c.connPrivs[i+1:]...)
+		}
+	case grantObject:
+		target, typ, err := g.target(x, c)
+		if err != nil {
+			return err
+		}
+		all := g.privileges[0] == "ALL"
+		removed := 0
+		objPrivs := c.objPrivs[:0]
+		for _, op := range c.objPrivs {
+			if op.schema == target.schema && op.name == target.name && op.typ == typ && op.grantee == grantee && (all || contains(g.privileges, op.privilege)) {
+				removed++
+				continue
+			}
+			objPrivs = append(objPrivs, op)
+		}
+		c.objPrivs = objPrivs
+		if removed == 0 {
+			return errorf("privilege has not been granted to %s", grantee)
+		}
+	case grantRestricted:
+		script, err := x.qualify(g.forScript)
+		if err != nil {
+			return err
+		}
+		i := c.findRestrictedPriv(restrictedPriv{connection: g.privileges[0], forSchema: script.schema, forName: script.name, grantee: grantee})
+		if i == -1 {
+			return errorf("access on connection %s has not been granted to %s", g.privileges[0], grantee)
+		}
+		c.restrictedPrivs = append(c.restrictedPrivs[:i], c.restrictedPrivs[i+1:]...)
+	case grantImpersonation:
+		i := c.findImpersonationPriv(grantee, g.privileges[0])
+		if i == -1 {
+			return errorf("impersonation on %s has not been granted to %s", g.privileges[0], grantee)
+		}
+		c.impersonationPrivs = append(c.impersonationPrivs[:i], c.impersonationPrivs[i+1:]...)
+	}
+	return nil
+}
+
+func (c *catalog) findSysPriv(grantee, privilege string) int {
+	for i, sp := range c.sysPrivs {
+		if sp.grantee == grantee && sp.privilege == privilege {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *catalog) findRolePriv(grantee, role string) int {
+	for i, rp := range c.rolePrivs {
+		if rp.grantee == grantee && rp.role == role {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *catalog) findConnPriv(grantee, connection string) int {
+	for i, cp := range c.connPrivs {
+		if cp.grantee == grantee && cp.connection == connection {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *catalog) findObjPriv(op objPriv) int {
+	for i, o := range c.objPrivs {
+		if o.schema == op.schema && o.name == op.name && o.typ == op.typ && o.privilege == op.privilege && o.grantee == op.grantee {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *catalog) findRestrictedPriv(rp restrictedPriv) int {
+	for i, r := range c.restrictedPrivs {
+		if r.connection == rp.connection && r.forSchema == rp.forSchema && r.forName == rp.forName && r.grantee == rp.grantee {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *catalog) findImpersonationPriv(grantee, on string) int {
+	for i, ip := range c.impersonationPrivs {
+		if ip.grantee == grantee && ip.on == on {
+			return i
+		}
+	}
+	return -1
+}
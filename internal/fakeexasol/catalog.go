@@ -0,0 +1,452 @@
+package fakeexasol
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	timestampFormat = "2006-01-02 15:04:05.000000"
+)
+
+// catalog is the in-memory state of the database
+type catalog struct {
+	nextID      int
+	schemas     map[string]*schemaObject
+	users       map[string]*userObject
+	roles       map[string]*roleObject
+	connections map[string]*connectionObject
+	// parameters are the system values of EXA_PARAMETERS
+	parameters map[string]string
+
+	sysPrivs           []sysPriv
+	rolePrivs          []rolePriv
+	objPrivs           []objPriv
+	connPrivs          []connPriv
+	restrictedPrivs    []restrictedPriv
+	impersonationPrivs []impersonationPriv
+}
+
+type schemaObject struct {
+	id      int
+	name    string
+	owner   string
+	comment string
+	created string
+	tables  map[string]*tableObject
+	views   map[string]*viewObject
+}
+
+type column struct {
+	name         string
+	typ          string
+	nullable     bool
+	comment      string
+	distribution bool
+	partition    bool
+	def          string
+	identity     bool
+}
+
+type constraint struct {
+	name       string
+	typ        string
+	enabled    bool
+	columns    []string
+	refSchema  string
+	refTable   string
+	refColumns []string
+}
+
+type tableObject struct {
+	id          int
+	name        string
+	owner       string
+	comment     string
+	created     string
+	columns     []*column
+	constraints []*constraint
+	rows        [][]interface{}
+}
+
+type viewObject struct {
+	id      int
+	name    string
+	owner   string
+	comment string
+	created string
+	// text is the full CREATE VIEW statement
+	text     string
+	subquery string
+	// scope is the schema unqualified names are resolved in
+	scope string
+	// columns are either declared or derived from subquery
+	columns []*column
+}
+
+type userObject struct {
+	id                  int
+	name                string
+	comment             string
+	created             string
+	password            string
+	ldap                string
+	kerberos            string
+	openid              string
+	passwordState       string
+	passwordChanged     string
+	passwordPolicy      string
+	failedLoginAttempts int
+}
+
+type roleObject struct {
+	id      int
+	name    string
+	comment string
+	created string
+}
+
+type connectionObject struct {
+	id         int
+	name       string
+	comment    string
+	created    string
+	connString string
+	user       string
+	password   string
+}
+
+type sysPriv struct {
+	grantee   string
+	privilege string
+	admin     bool
+}
+
+type rolePriv struct {
+	grantee string
+	role    string
+	admin   bool
+}
+
+type objPriv struct {
+	schema    string
+	name      string
+	typ       string
+	privilege string
+	grantee   string
+	grantor   string
+}
+
+type connPriv struct {
+	grantee    string
+	connection string
+	admin      bool
+}
+
+type restrictedPriv struct {
+	connection string
+	forSchema  string
+	forName    string
+	grantee    string
+	grantor    string
+}
+
+type impersonationPriv struct {
+	grantee string
+	on      string
+	grantor string
+}
+
+func newCatalog(user, password string) *catalog {
+	c := &catalog{
+		schemas:     map[string]*schemaObject{},
+		users:       map[string]*userObject{},
+		roles:       map[string]*roleObject{},
+		connections: map[string]*connectionObject{},
+		parameters:  defaultParameters(),
+	}
+	now := time.Now().UTC().Format(timestampFormat)
+	c.users[user] = &userObject{
+		id:              c.newID(),
+		name:            user,
+		created:         now,
+		password:        password,
+		passwordState:   "VALID",
+		passwordChanged: now,
+	}
+	for _, r := range []string{"PUBLIC", "DBA"} {
+		c.roles[r] = &roleObject{
+			id:      c.newID(),
+			name:    r,
+			created: now,
+		}
+	}
+	c.rolePrivs = append(c.rolePrivs, rolePriv{
+		grantee: user,
+		role:    "DBA",
+		admin:   true,
+	})
+	return c
+}
+
+func defaultParameters() map[string]string {
+	return map[string]string{
+		"NLS_DATE_FORMAT":               "YYYY-MM-DD",
+		"NLS_DATE_LANGUAGE":             "ENG",
+		"NLS_FIRST_DAY_OF_WEEK":         "7",
+		"NLS_NUMERIC_CHARACTERS":        ".,",
+		"NLS_TIMESTAMP_FORMAT":          "YYYY-MM-DD HH24:MI:SS.FF6",
+		"PASSWORD_SECURITY_POLICY":      "OFF",
+		"PASSWORD_EXPIRY_POLICY":        "OFF",
+		"QUERY_CACHE":                   "ON",
+		"QUERY_TIMEOUT":                 "0",
+		"SQL_PREPROCESSOR_SCRIPT":       "",
+		"TIME_ZONE":                     "UTC",
+		"TIME_ZONE_BEHAVIOR":            "INVALID SHIFT AMBIGUOUS ST",
+		"DEFAULT_LIKE_ESCAPE_CHARACTER": "\\",
+		"CONSTRAINT_STATE_DEFAULT":      "ENABLE",
+		"PROFILE":                       "OFF",
+		"SCRIPT_LANGUAGES":              "PYTHON3=builtin_python3 R=builtin_r JAVA=builtin_java",
+		"SNAPSHOT_MODE":                 "OFF",
+	}
+}
+
+func (c *catalog) newID() int {
+	c.nextID++
+	return c.nextID
+}
+
+// clone deep copies the catalog so that a Transaction can work
+// on its own snapshot
+func (c *catalog) clone() *catalog {
+	n := &catalog{
+		nextID:      c.nextID,
+		schemas:     make(map[string]*schemaObject, len(c.schemas)),
+		users:       make(map[string]*userObject, len(c.users)),
+		roles:       make(map[string]*roleObject, len(c.roles)),
+		connections: make(map[string]*connectionObject, len(c.connections)),
+		parameters:  make(map[string]string, len(c.parameters)),
+
+		sysPrivs:           append([]sysPriv(nil), c.sysPrivs...),
+		rolePrivs:          append([]rolePriv(nil), c.rolePrivs...),
+		objPrivs:           append([]objPriv(nil), c.objPrivs...),
+		connPrivs:          append([]connPriv(nil), c.connPrivs...),
+		restrictedPrivs:    append([]restrictedPriv(nil), c.restrictedPrivs...),
+		impersonationPrivs: append([]impersonationPriv(nil), c.impersonationPrivs...),
+	}
+	for k, s := range c.schemas {
+		n.schemas[k] = s.clone()
+	}
+	for k, u := range c.users {
+		cu := *u
+		n.users[k] = &cu
+	}
+	for k, r := range c.roles {
+		cr := *r
+		n.roles[k] = &cr
+	}
+	for k, conn := range c.connections {
+		cc := *conn
+		n.connections[k] = &cc
+	}
+	for k, v := range c.parameters {
+		n.parameters[k] = v
+	}
+	return n
+}
+
+func (s *schemaObject) clone() *schemaObject {
+	n := *s
+	n.tables = make(map[string]*tableObject, len(s.tables))
+	n.views = make(map[string]*viewObject, len(s.views))
+	for k, t := range s.tables {
+		n.tables[k] = t.clone()
+	}
+	for k, v := range s.views {
+		cv := *v
+		cv.columns = cloneColumns(v.columns)
+		n.views[k] = &cv
+	}
+	return &n
+}
+
+func (t *tableObject) clone() *tableObject {
+	n := *t
+	n.columns = cloneColumns(t.columns)
+	n.constraints = make([]*constraint, 0, len(t.constraints))
+	for _, con := range t.constraints {
+		cc := *con
+		cc.columns = append([]string(nil), con.columns...)
+		cc.refColumns = append([]string(nil), con.refColumns...)
+		n.constraints = append(n.constraints, &cc)
+	}
+	n.rows = make([][]interface{}, 0, len(t.rows))
+	for _, r := range t.rows {
+		n.rows = append(n.rows, append([]interface{}(nil), r...))
+	}
+	return &n
+}
+
+func cloneColumns(columns []*column) []*column {
+	n := make([]*column, 0, len(columns))
+	for _, col := range columns {
+		cc := *col
+		n = append(n, &cc)
+	}
+	return n
+}
+
+func (c *catalog) schema(name string) (*schemaObject, error) {
+	s, ok := c.schemas[name]
+	if !ok {
+		return nil, errorf("schema %s not found", name)
+	}
+	return s, nil
+}
+
+// object looks up a Table or View
+func (c *catalog) object(n objectName) (*tableObject, *viewObject, error) {
+	s, err := c.schema(n.schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t, ok := s.tables[n.name]; ok {
+		return t, nil, nil
+	}
+	if v, ok := s.views[n.name]; ok {
+		return nil, v, nil
+	}
+	return nil, nil, errNotFound(n.String())
+}
+
+func (c *catalog) table(n objectName) (*tableObject, error) {
+	t, _, err := c.object(n)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, errorf("%s is not a table", n)
+	}
+	return t, nil
+}
+
+// objectExists reports whether a Table or View of name exists in s
+func (s *schemaObject) objectExists(name string) bool {
+	_, isTable := s.tables[name]
+	_, isView := s.views[name]
+	return isTable || isView
+}
+
+// grantee reports whether name is a User or Role
+func (c *catalog) isGrantee(name string) bool {
+	_, isUser := c.users[name]
+	_, isRole := c.roles[name]
+	return isUser || isRole
+}
+
+// dropGrantee removes all privileges granted to name
+func (c *catalog) dropGrantee(name string) {
+	sysPrivs := c.sysPrivs[:0]
+	for _, p := range c.sysPrivs {
+		if p.grantee != name {
+			sysPrivs = append(sysPrivs, p)
+		}
+	}
+	c.sysPrivs = sysPrivs
+
+	rolePrivs := c.rolePrivs[:0]
+	for _, p := range c.rolePrivs {
+		if p.grantee != name && p.role != name {
+			rolePrivs = append(rolePrivs, p)
+		}
+	}
+	c.rolePrivs = rolePrivs
+
+	objPrivs := c.objPrivs[:0]
+	for _, p := range c.objPrivs {
+		if p.grantee != name {
+			objPrivs = append(objPrivs, p)
+		}
+	}
+	c.objPrivs = objPrivs
+
+	connPrivs := c.connPrivs[:0]
+	for _, p := range c.connPrivs {
+		if p.grantee != name {
+			connPrivs = append(connPrivs, p)
+		}
+	}
+	c.connPrivs = connPrivs
+
+	restrictedPrivs := c.restrictedPrivs[:0]
+	for _, p := range c.restrictedPrivs {
+		if p.grantee != name {
+			restrictedPrivs = append(restrictedPrivs, p)
+		}
+	}
+	c.restrictedPrivs = restrictedPrivs
+
+	impersonationPrivs := c.impersonationPrivs[:0]
+	for _, p := range c.impersonationPrivs {
+		if p.grantee != name && p.on != name {
+			impersonationPrivs = append(impersonationPrivs, p)
+		}
+	}
+	c.impersonationPrivs = impersonationPrivs
+}
+
+// dropObjectPrivs removes privileges on schema.name or
+// on all objects of schema if name is empty
+func (c *catalog) dropObjectPrivs(schema, name string) {
+	objPrivs := c.objPrivs[:0]
+	for _, p := range c.objPrivs {
+		if p.schema == schema && (name == "" || p.name == name) {
+			continue
+		}
+		if name == "" && p.typ == "SCHEMA" && p.name == schema {
+			continue
+		}
+		objPrivs = append(objPrivs, p)
+	}
+	c.objPrivs = objPrivs
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	switch t := m.(type) {
+	case map[string]*schemaObject:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]*tableObject:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]*viewObject:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]*userObject:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]*roleObject:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]*connectionObject:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]bool:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]string:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,1479 @@
+package fakeexasol
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (p *parser) create() (mutation, error) {
+	replace := p.accept("OR", "REPLACE")
+	force := p.accept("FORCE")
+	switch {
+	case p.accept("SCHEMA"):
+		return p.createSchema()
+	case p.accept("TABLE"):
+		return p.createTable(replace)
+	case p.accept("VIEW"):
+		return p.createView(replace, force)
+	case p.accept("ROLE"):
+		return p.createRole()
+	case p.accept("USER"):
+		return p.createUser()
+	case p.accept("CONNECTION"):
+		return p.createConnection(replace)
+	}
+	return nil, errorf("CREATE %s is not supported by the fake database", p.peek())
+}
+
+func (p *parser) drop() (mutation, error) {
+	switch {
+	case p.accept("SCHEMA"):
+		return p.dropSchema()
+	case p.accept("TABLE"):
+		return p.dropTable()
+	case p.accept("VIEW"):
+		return p.dropView()
+	case p.accept("ROLE"):
+		return p.dropRole()
+	case p.accept("USER"):
+		return p.dropUser()
+	case p.accept("CONNECTION"):
+		return p.dropConnection()
+	}
+	return nil, errorf("DROP %s is not supported by the fake database", p.peek())
+}
+
+func (p *parser) alter() (mutation, error) {
+	switch {
+	case p.accept("TABLE"):
+		return p.alterTable()
+	case p.accept("SCHEMA"):
+		return p.alterSchema()
+	case p.accept("USER"):
+		return p.alterUser()
+	case p.accept("CONNECTION"):
+		return p.createConnectionWith(false, true)
+	case p.accept("SYSTEM"):
+		key, value, err := p.parameterAssignment()
+		if err != nil {
+			return nil, err
+		}
+		return func(x *execContext, c *catalog) (int, error) {
+			c.parameters[key] = value
+			return 0, nil
+		}, nil
+	}
+	return nil, errorf("ALTER %s is not supported by the fake database", p.peek())
+}
+
+func (p *parser) createSchema() (mutation, error) {
+	ifNotExists := p.accept("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		if _, ok := c.schemas[name]; ok {
+			if ifNotExists {
+				return 0, nil
+			}
+			return 0, errExists(name)
+		}
+		c.schemas[name] = &schemaObject{
+			id:      c.newID(),
+			name:    name,
+			owner:   x.user,
+			created: x.now(),
+			tables:  map[string]*tableObject{},
+			views:   map[string]*viewObject{},
+		}
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) dropSchema() (mutation, error) {
+	ifExists := p.accept("IF", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	cascade := p.accept("CASCADE")
+	p.accept("RESTRICT")
+	return func(x *execContext, c *catalog) (int, error) {
+		s, ok := c.schemas[name]
+		if !ok {
+			if ifExists {
+				return 0, nil
+			}
+			return 0, errorf("schema %s not found", name)
+		}
+		if !cascade && (len(s.tables) != 0 || len(s.views) != 0) {
+			return 0, errorf("schema %s is not empty - use DROP SCHEMA %s CASCADE to delete it", name, name)
+		}
+		for _, t := range s.tables {
+			c.dropForeignKeysTo(name, t.name)
+		}
+		delete(c.schemas, name)
+		c.dropObjectPrivs(name, "")
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) alterSchema() (mutation, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept("CHANGE", "OWNER") {
+		owner, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		return func(x *execContext, c *catalog) (int, error) {
+			s, err := c.schema(name)
+			if err != nil {
+				return 0, err
+			}
+			if !c.isGrantee(owner) {
+				return 0, errorf("user or role %s not found", owner)
+			}
+			s.owner = owner
+			return 0, nil
+		}, nil
+	}
+	if p.accept("SET", "RAW_SIZE_LIMIT", "=") {
+		_, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return func(x *execContext, c *catalog) (int, error) {
+			_, err := c.schema(name)
+			return 0, err
+		}, nil
+	}
+	return nil, p.unexpected("CHANGE OWNER or SET RAW_SIZE_LIMIT")
+}
+
+// tableDefinition is the parsed content of CREATE TABLE
+type tableDefinition struct {
+	columns     []*column
+	constraints []*constraint
+	like        *objectName
+	likeComment bool
+	likeDefault bool
+	// likeAt is the position of the LIKE columns in columns
+	likeAt       int
+	distribution []string
+	partition    []string
+	subquery     *selectStmt
+	withNoData   bool
+	comment      *string
+}
+
+func (p *parser) createTable(replace bool) (mutation, error) {
+	ifNotExists := p.accept("IF", "NOT", "EXISTS")
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+
+	def := &tableDefinition{}
+	if p.accept("LIKE") {
+		err = p.tableLike(def)
+		if err != nil {
+			return nil, err
+		}
+	} else if p.accept("AS") {
+		def.subquery, err = p.query()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept("WITH", "NO", "DATA") {
+			def.withNoData = true
+		} else {
+			p.accept("WITH", "DATA")
+		}
+	} else {
+		err = p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+		for {
+			if p.accept("LIKE") {
+				err = p.tableLike(def)
+			} else {
+				err = p.tableElement(def)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if p.accept(")") {
+				break
+			}
+			err = p.expect(",")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	def.comment, err = p.optionalComment()
+	if err != nil {
+		return nil, err
+	}
+	return def.create(name, replace, ifNotExists), nil
+}
+
+func (def *tableDefinition) create(name objectName, replace, ifNotExists bool) mutation {
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		s, err := c.schema(qn.schema)
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := s.views[qn.name]; ok {
+			return 0, errExists(qn.String())
+		}
+		if _, ok := s.tables[qn.name]; ok {
+			if ifNotExists {
+				return 0, nil
+			}
+			if !replace {
+				return 0, errExists(qn.String())
+			}
+		}
+
+		t := &tableObject{
+			id:      c.newID(),
+			name:    qn.name,
+			owner:   x.user,
+			created: x.now(),
+		}
+		if def.comment != nil {
+			t.comment = *def.comment
+		}
+
+		switch {
+		case def.subquery != nil:
+			rel, err := def.subquery.run(x, c)
+			if err != nil {
+				return 0, err
+			}
+			for _, col := range rel.columns {
+				t.columns = append(t.columns, &column{name: col.name, typ: col.typ, nullable: true})
+			}
+			if !def.withNoData {
+				t.rows = rel.rows
+			}
+		default:
+			t.columns = cloneColumns(def.columns)
+			for _, con := range def.constraints {
+				cc := *con
+				t.constraints = append(t.constraints, &cc)
+			}
+		}
+		if def.like != nil {
+			like, err := def.likeColumns(x, c)
+			if err != nil {
+				return 0, err
+			}
+			t.columns = append(t.columns[:def.likeAt], append(like, t.columns[def.likeAt:]...)...)
+		}
+		err = markColumns(t.columns, def.distribution, func(c *column) { c.distribution = true })
+		if err != nil {
+			return 0, err
+		}
+		err = markColumns(t.columns, def.partition, func(c *column) { c.partition = true })
+		if err != nil {
+			return 0, err
+		}
+
+		err = t.validate(x, c, qn.schema)
+		if err != nil {
+			return 0, err
+		}
+		for _, con := range t.constraints {
+			if con.name == "" {
+				con.name = fmt.Sprintf("SYS_%d", c.newID())
+			}
+		}
+		s.tables[qn.name] = t
+		return len(t.rows), nil
+	}
+}
+
+func (p *parser) tableLike(def *tableDefinition) error {
+	n, err := p.objectName()
+	if err != nil {
+		return err
+	}
+	def.like = &n
+	def.likeAt = len(def.columns)
+	for {
+		including, ok := p.acceptAny("INCLUDING", "EXCLUDING")
+		if !ok {
+			return nil
+		}
+		option, ok := p.acceptAny("DEFAULTS", "IDENTITY", "COMMENTS")
+		if !ok {
+			return p.unexpected("DEFAULTS, IDENTITY or COMMENTS")
+		}
+		switch option {
+		case "COMMENTS":
+			def.likeComment = including == "INCLUDING"
+		case "DEFAULTS":
+			def.likeDefault = including == "INCLUDING"
+		}
+	}
+}
+
+// likeColumns copies the columns of the object of LIKE
+func (def *tableDefinition) likeColumns(x *execContext, c *catalog) ([]*column, error) {
+	ln, err := x.qualify(*def.like)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := loadObject(x, c, ln)
+	if err != nil {
+		return nil, err
+	}
+	source, _, _ := c.object(ln)
+	columns := []*column{}
+	for i, col := range rel.columns {
+		nc := &column{name: col.name, typ: col.typ, nullable: col.nullable}
+		if source != nil {
+			if def.likeComment {
+				nc.comment = source.columns[i].comment
+			}
+			if def.likeDefault {
+				nc.def = source.columns[i].def
+			}
+		}
+		columns = append(columns, nc)
+	}
+	return columns, nil
+}
+
+// tableElement reads a column definition or table constraint
+func (p *parser) tableElement(def *tableDefinition) error {
+	if p.accept("DISTRIBUTE", "BY") {
+		names, err := p.bareNameList()
+		def.distribution = append(def.distribution, names...)
+		return err
+	}
+	if p.accept("PARTITION", "BY") {
+		names, err := p.bareNameList()
+		def.partition = append(def.partition, names...)
+		return err
+	}
+
+	con, ok, err := p.tableConstraint()
+	if err != nil {
+		return err
+	}
+	if ok {
+		def.constraints = append(def.constraints, con)
+		return nil
+	}
+
+	col, cons, err := p.columnDefinition()
+	if err != nil {
+		return err
+	}
+	def.columns = append(def.columns, col)
+	def.constraints = append(def.constraints, cons...)
+	return nil
+}
+
+// bareNameList reads a comma separated list of names without parenthesis
+func (p *parser) bareNameList() ([]string, error) {
+	names := []string{}
+	for {
+		n, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, n)
+		if !p.peek().is(",") || p.peekN(1).kind != tokenIdent || reservedTableElement[p.peekN(1).text] {
+			return names, nil
+		}
+		// Only consume the comma if another distribution column follows
+		if p.peekN(2).is(",") || p.peekN(2).is(")") {
+			p.next()
+			continue
+		}
+		return names, nil
+	}
+}
+
+var reservedTableElement = map[string]bool{
+	"CONSTRAINT": true, "PRIMARY": true, "FOREIGN": true, "DISTRIBUTE": true,
+	"PARTITION": true, "LIKE": true,
+}
+
+func markColumns(columns []*column, names []string, mark func(*column)) error {
+	for _, n := range names {
+		found := false
+		for _, c := range columns {
+			if c.name == n {
+				mark(c)
+				found = true
+			}
+		}
+		if !found {
+			return errorf("column %s not found", n)
+		}
+	}
+	return nil
+}
+
+// tableConstraint reads [CONSTRAINT [name]] PRIMARY KEY (...) or FOREIGN KEY (...) REFERENCES ...
+func (p *parser) tableConstraint() (*constraint, bool, error) {
+	start := p.i
+	con := &constraint{enabled: true}
+	if p.accept("CONSTRAINT") {
+		if !p.peek().is("PRIMARY") && !p.peek().is("FOREIGN") {
+			n, err := p.name()
+			if err != nil {
+				return nil, false, err
+			}
+			con.name = n
+		}
+	}
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		con.typ = "PRIMARY KEY"
+		cols, err := p.nameList()
+		if err != nil {
+			return nil, false, err
+		}
+		con.columns = cols
+	case p.accept("FOREIGN", "KEY"):
+		con.typ = "FOREIGN KEY"
+		cols, err := p.nameList()
+		if err != nil {
+			return nil, false, err
+		}
+		con.columns = cols
+		err = p.references(con)
+		if err != nil {
+			return nil, false, err
+		}
+	default:
+		p.i = start
+		return nil, false, nil
+	}
+	p.constraintState(con)
+	return con, true, nil
+}
+
+func (p *parser) references(con *constraint) error {
+	err := p.expect("REFERENCES")
+	if err != nil {
+		return err
+	}
+	ref, err := p.objectName()
+	if err != nil {
+		return err
+	}
+	con.refSchema = ref.schema
+	con.refTable = ref.name
+	if p.peek().is("(") {
+		con.refColumns, err = p.nameList()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) constraintState(con *constraint) {
+	if state, ok := p.acceptAny("ENABLE", "DISABLE"); ok {
+		con.enabled = state == "ENABLE"
+	}
+}
+
+// columnDefinition reads name type [DEFAULT ..] [IDENTITY] [constraints] [COMMENT IS ..]
+func (p *parser) columnDefinition() (*column, []*constraint, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, nil, err
+	}
+	typ, err := p.dataType()
+	if err != nil {
+		return nil, nil, err
+	}
+	col := &column{name: name, typ: typ, nullable: true}
+	cons := []*constraint{}
+	for {
+		switch {
+		case p.accept("DEFAULT"):
+			start := p.i
+			_, err := p.additive()
+			if err != nil {
+				return nil, nil, err
+			}
+			col.def = p.raw(start, p.i)
+			continue
+		case p.accept("IDENTITY"):
+			col.identity = true
+			if p.peek().kind == tokenNumber {
+				p.next()
+			}
+			continue
+		}
+
+		con := &constraint{enabled: true, columns: []string{name}}
+		named := false
+		if p.accept("CONSTRAINT") {
+			named = true
+			if !p.peek().is("PRIMARY") && !p.peek().is("NOT") && !p.peek().is("NULL") && !p.peek().is("REFERENCES") {
+				con.name, err = p.name()
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		switch {
+		case p.accept("NOT", "NULL"):
+			p.constraintState(con)
+			col.nullable = !con.enabled
+			continue
+		case p.accept("NULL"):
+			col.nullable = true
+			continue
+		case p.accept("PRIMARY", "KEY"):
+			con.typ = "PRIMARY KEY"
+		case p.peek().is("REFERENCES"):
+			con.typ = "FOREIGN KEY"
+			err = p.references(con)
+			if err != nil {
+				return nil, nil, err
+			}
+		default:
+			if named {
+				return nil, nil, p.unexpected("constraint")
+			}
+			comment, err := p.optionalComment()
+			if err != nil {
+				return nil, nil, err
+			}
+			if comment != nil {
+				col.comment = *comment
+			}
+			return col, cons, nil
+		}
+		p.constraintState(con)
+		cons = append(cons, con)
+	}
+}
+
+// validate checks columns and constraints of a new Table
+func (t *tableObject) validate(x *execContext, c *catalog, schema string) error {
+	seen := map[string]bool{}
+	for _, col := range t.columns {
+		if seen[col.name] {
+			return errorf("duplicate column name %s", col.name)
+		}
+		seen[col.name] = true
+	}
+	primaryKeys := 0
+	for _, con := range t.constraints {
+		for _, name := range con.columns {
+			if !seen[name] {
+				return errorf("column %s not found", name)
+			}
+		}
+		switch con.typ {
+		case "PRIMARY KEY":
+			primaryKeys++
+			if primaryKeys > 1 {
+				return errorf("table %s has more than one primary key", t.name)
+			}
+			if con.enabled {
+				markColumns(t.columns, con.columns, func(col *column) { col.nullable = false })
+			}
+		case "FOREIGN KEY":
+			if con.refSchema == "" {
+				con.refSchema = schema
+			}
+			var ref *tableObject
+			if con.refSchema == schema && con.refTable == t.name {
+				ref = t
+			} else {
+				var err error
+				ref, err = c.table(objectName{schema: con.refSchema, name: con.refTable})
+				if err != nil {
+					return err
+				}
+			}
+			pk := ref.primaryKey()
+			if pk == nil {
+				return errorf("referenced table %s.%s has no primary key", con.refSchema, con.refTable)
+			}
+			if len(con.refColumns) == 0 {
+				con.refColumns = append([]string(nil), pk.columns...)
+			}
+			if len(con.refColumns) != len(con.columns) {
+				return errorf("foreign key %s does not match primary key of %s.%s", t.name, con.refSchema, con.refTable)
+			}
+		}
+	}
+	return nil
+}
+
+func (t *tableObject) primaryKey() *constraint {
+	for _, con := range t.constraints {
+		if con.typ == "PRIMARY KEY" {
+			return con
+		}
+	}
+	return nil
+}
+
+func (t *tableObject) columnIndex(name string) int {
+	for i, col := range t.columns {
+		if col.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// referencingForeignKeys reports whether other Tables reference schema.name
+func (c *catalog) referencingForeignKeys(schema, name string) bool {
+	for _, s := range c.schemas {
+		for _, t := range s.tables {
+			if s.name == schema && t.name == name {
+				continue
+			}
+			for _, con := range t.constraints {
+				if con.typ == "FOREIGN KEY" && con.refSchema == schema && con.refTable == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (c *catalog) dropForeignKeysTo(schema, name string) {
+	for _, s := range c.schemas {
+		for _, t := range s.tables {
+			cons := t.constraints[:0]
+			for _, con := range t.constraints {
+				if con.typ == "FOREIGN KEY" && con.refSchema == schema && con.refTable == name {
+					continue
+				}
+				cons = append(cons, con)
+			}
+			t.constraints = cons
+		}
+	}
+}
+
+func (p *parser) dropTable() (mutation, error) {
+	ifExists := p.accept("IF", "EXISTS")
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+	cascade := p.accept("CASCADE", "CONSTRAINTS")
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		s, ok := c.schemas[qn.schema]
+		if !ok || s.tables[qn.name] == nil {
+			if ifExists {
+				return 0, nil
+			}
+			return 0, errNotFound(qn.String())
+		}
+		if c.referencingForeignKeys(qn.schema, qn.name) {
+			if !cascade {
+				return 0, errorf("table %s is referenced by a foreign key - use CASCADE CONSTRAINTS", qn)
+			}
+			c.dropForeignKeysTo(qn.schema, qn.name)
+		}
+		delete(s.tables, qn.name)
+		c.dropObjectPrivs(qn.schema, qn.name)
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) alterTable() (mutation, error) {
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+	var change func(x *execContext, c *catalog, schema string, t *tableObject) error
+
+	switch {
+	case p.accept("ADD"):
+		con, ok, err := p.tableConstraint()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			change = func(x *execContext, c *catalog, schema string, t *tableObject) error {
+				cc := *con
+				t.constraints = append(t.constraints, &cc)
+				err := t.validate(x, c, schema)
+				if err != nil {
+					return err
+				}
+				if cc.name == "" {
+					cc.name = fmt.Sprintf("SYS_%d", c.newID())
+				}
+				return nil
+			}
+			break
+		}
+		p.accept("COLUMN")
+		ifNotExists := p.accept("IF", "NOT", "EXISTS")
+		col, cons, err := p.columnDefinition()
+		if err != nil {
+			return nil, err
+		}
+		change = func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			if t.columnIndex(col.name) != -1 {
+				if ifNotExists {
+					return nil
+				}
+				return errorf("column %s already exists", col.name)
+			}
+			cc := *col
+			t.columns = append(t.columns, &cc)
+			for i := range t.rows {
+				t.rows[i] = append(t.rows[i], nil)
+			}
+			for _, con := range cons {
+				ccon := *con
+				t.constraints = append(t.constraints, &ccon)
+			}
+			return t.validate(x, c, schema)
+		}
+	case p.accept("DROP"):
+		change, err = p.alterTableDrop()
+		if err != nil {
+			return nil, err
+		}
+	case p.accept("MODIFY"):
+		if p.accept("CONSTRAINT") {
+			conName, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			state, ok := p.acceptAny("ENABLE", "DISABLE")
+			if !ok {
+				return nil, p.unexpected("ENABLE or DISABLE")
+			}
+			change = func(x *execContext, c *catalog, schema string, t *tableObject) error {
+				for _, con := range t.constraints {
+					if con.name == conName {
+						con.enabled = state == "ENABLE"
+						return nil
+					}
+				}
+				return errorf("constraint %s not found", conName)
+			}
+			break
+		}
+		p.accept("COLUMN")
+		wrapped := p.accept("(")
+		col, cons, err := p.columnDefinition()
+		if err != nil {
+			return nil, err
+		}
+		if wrapped {
+			err = p.expect(")")
+			if err != nil {
+				return nil, err
+			}
+		}
+		change = func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			i := t.columnIndex(col.name)
+			if i == -1 {
+				return errorf("column %s not found", col.name)
+			}
+			cc := *col
+			cc.distribution = t.columns[i].distribution
+			cc.partition = t.columns[i].partition
+			if cc.comment == "" {
+				cc.comment = t.columns[i].comment
+			}
+			t.columns[i] = &cc
+			for _, con := range cons {
+				ccon := *con
+				t.constraints = append(t.constraints, &ccon)
+			}
+			return t.validate(x, c, schema)
+		}
+	case p.accept("RENAME"):
+		kind, ok := p.acceptAny("COLUMN", "CONSTRAINT")
+		if !ok {
+			return nil, p.unexpected("COLUMN or CONSTRAINT")
+		}
+		old, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expect("TO")
+		if err != nil {
+			return nil, err
+		}
+		new, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		change = func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			if kind == "CONSTRAINT" {
+				for _, con := range t.constraints {
+					if con.name == old {
+						con.name = new
+						return nil
+					}
+				}
+				return errorf("constraint %s not found", old)
+			}
+			i := t.columnIndex(old)
+			if i == -1 {
+				return errorf("column %s not found", old)
+			}
+			if t.columnIndex(new) != -1 {
+				return errorf("column %s already exists", new)
+			}
+			t.columns[i].name = new
+			for _, con := range t.constraints {
+				renameIn(con.columns, old, new)
+			}
+			c.renameReferencedColumn(schema, t.name, old, new)
+			return nil
+		}
+	case p.accept("ALTER"):
+		p.accept("COLUMN")
+		colName, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		def := ""
+		if p.accept("SET", "DEFAULT") {
+			start := p.i
+			_, err = p.additive()
+			if err != nil {
+				return nil, err
+			}
+			def = p.raw(start, p.i)
+		} else if !p.accept("DROP", "DEFAULT") {
+			return nil, p.unexpected("SET DEFAULT or DROP DEFAULT")
+		}
+		change = func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			i := t.columnIndex(colName)
+			if i == -1 {
+				return errorf("column %s not found", colName)
+			}
+			t.columns[i].def = def
+			return nil
+		}
+	case p.accept("DISTRIBUTE", "BY"), p.accept("PARTITION", "BY"):
+		partition := p.tokens[p.i-2].is("PARTITION")
+		names := []string{}
+		for {
+			n, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, n)
+			if !p.accept(",") {
+				break
+			}
+		}
+		change = func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			for _, col := range t.columns {
+				if partition {
+					col.partition = false
+				} else {
+					col.distribution = false
+				}
+			}
+			return markColumns(t.columns, names, func(col *column) {
+				if partition {
+					col.partition = true
+				} else {
+					col.distribution = true
+				}
+			})
+		}
+	default:
+		return nil, p.unexpected("ADD, DROP, MODIFY, RENAME, ALTER or DISTRIBUTE BY")
+	}
+
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		t, err := c.table(qn)
+		if err != nil {
+			return 0, err
+		}
+		return 0, change(x, c, qn.schema, t)
+	}, nil
+}
+
+func (p *parser) alterTableDrop() (func(x *execContext, c *catalog, schema string, t *tableObject) error, error) {
+	switch {
+	case p.accept("CONSTRAINT"):
+		conName, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		return func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			for i, con := range t.constraints {
+				if con.name == conName {
+					t.constraints = append(t.constraints[:i], t.constraints[i+1:]...)
+					return nil
+				}
+			}
+			return errorf("constraint %s not found", conName)
+		}, nil
+	case p.accept("PRIMARY", "KEY"):
+		return func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			for i, con := range t.constraints {
+				if con.typ == "PRIMARY KEY" {
+					t.constraints = append(t.constraints[:i], t.constraints[i+1:]...)
+					return nil
+				}
+			}
+			return errorf("table %s has no primary key", t.name)
+		}, nil
+	case p.accept("DISTRIBUTION", "AND", "PARTITION", "KEYS"):
+		return func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			for _, col := range t.columns {
+				col.distribution = false
+				col.partition = false
+			}
+			return nil
+		}, nil
+	case p.accept("DISTRIBUTION", "KEYS"):
+		return func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			for _, col := range t.columns {
+				col.distribution = false
+			}
+			return nil
+		}, nil
+	case p.accept("PARTITION", "KEYS"):
+		return func(x *execContext, c *catalog, schema string, t *tableObject) error {
+			for _, col := range t.columns {
+				col.partition = false
+			}
+			return nil
+		}, nil
+	}
+
+	p.accept("COLUMN")
+	ifExists := p.accept("IF", "EXISTS")
+	colName, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	p.accept("CASCADE", "CONSTRAINTS")
+	return func(x *execContext, c *catalog, schema string, t *tableObject) error {
+		i := t.columnIndex(colName)
+		if i == -1 {
+			if ifExists {
+				return nil
+			}
+			return errorf("column %s not found", colName)
+		}
+		t.columns = append(t.columns[:i], t.columns[i+1:]...)
+		for r, row := range t.rows {
+			t.rows[r] = append(row[:i], row[i+1:]...)
+		}
+		cons := t.constraints[:0]
+		for _, con := range t.constraints {
+			if !contains(con.columns, colName) {
+				cons = append(cons, con)
+			}
+		}
+		t.constraints = cons
+		return nil
+	}, nil
+}
+
+func (c *catalog) renameReferencedColumn(schema, table, old, new string) {
+	for _, s := range c.schemas {
+		for _, t := range s.tables {
+			for _, con := range t.constraints {
+				if con.typ == "FOREIGN KEY" && con.refSchema == schema && con.refTable == table {
+					renameIn(con.refColumns, old, new)
+				}
+			}
+		}
+	}
+}
+
+func renameIn(names []string, old, new string) {
+	for i, n := range names {
+		if n == old {
+			names[i] = new
+		}
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) createView(replace, force bool) (mutation, error) {
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+
+	declared := []*column{}
+	if p.accept("(") {
+		for {
+			n, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			col := &column{name: n, nullable: true}
+			comment, err := p.optionalComment()
+			if err != nil {
+				return nil, err
+			}
+			if comment != nil {
+				col.comment = *comment
+			}
+			declared = append(declared, col)
+			if p.accept(")") {
+				break
+			}
+			err = p.expect(",")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = p.expect("AS")
+	if err != nil {
+		return nil, err
+	}
+	start := p.i
+	_, err = p.query()
+	if err != nil {
+		return nil, err
+	}
+	subquery := p.raw(start, p.i)
+	comment, err := p.optionalComment()
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimRight(strings.TrimSpace(p.sql), "; \t\r\n")
+
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		s, err := c.schema(qn.schema)
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := s.tables[qn.name]; ok {
+			return 0, errExists(qn.String())
+		}
+		if _, ok := s.views[qn.name]; ok && !replace {
+			return 0, errExists(qn.String())
+		}
+
+		v := &viewObject{
+			id:       c.newID(),
+			name:     qn.name,
+			owner:    x.user,
+			created:  x.now(),
+			text:     text,
+			subquery: subquery,
+			scope:    x.schema,
+			columns:  cloneColumns(declared),
+		}
+		if v.scope == "" {
+			v.scope = qn.schema
+		}
+		if comment != nil {
+			v.comment = *comment
+		}
+
+		rel, err := v.run(x, c)
+		if err != nil && !force {
+			return 0, err
+		}
+		if err == nil {
+			if len(declared) != 0 && len(declared) != len(rel.columns) {
+				return 0, errorf("number of columns of view %s does not match its query", qn)
+			}
+			for i, col := range rel.columns {
+				if i < len(v.columns) {
+					v.columns[i].typ = col.typ
+					continue
+				}
+				v.columns = append(v.columns, &column{name: col.name, typ: col.typ, nullable: true})
+			}
+		}
+		s.views[qn.name] = v
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) dropView() (mutation, error) {
+	ifExists := p.accept("IF", "EXISTS")
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+	p.acceptAny("CASCADE", "RESTRICT")
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		s, ok := c.schemas[qn.schema]
+		if !ok || s.views[qn.name] == nil {
+			if ifExists {
+				return 0, nil
+			}
+			return 0, errNotFound(qn.String())
+		}
+		delete(s.views, qn.name)
+		c.dropObjectPrivs(qn.schema, qn.name)
+		return 0, nil
+	}, nil
+}
+
+func (p *parser) rename() (mutation, error) {
+	kind, _ := p.acceptAny("SCHEMA", "TABLE", "VIEW", "OBJECT", "USER", "ROLE", "CONNECTION")
+	old, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expect("TO")
+	if err != nil {
+		return nil, err
+	}
+	new, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(x *execContext, c *catalog) (int, error) {
+		k := kind
+		if k == "" || k == "OBJECT" {
+			k = c.kindOf(x, old)
+		}
+		switch k {
+		case "SCHEMA":
+			return 0, c.renameSchema(old.name, new.name)
+		case "USER", "ROLE":
+			return 0, c.renameGrantee(k, old.name, new.name)
+		case "CONNECTION":
+			return 0, c.renameConnection(old.name, new.name)
+		case "TABLE", "VIEW":
+			return 0, c.renameObject(x, k, old, new)
+		}
+		return 0, errNotFound(old.String())
+	}, nil
+}
+
+// kindOf guesses the object type for RENAME without type
+func (c *catalog) kindOf(x *execContext, n objectName) string {
+	if n.schema == "" {
+		switch {
+		case c.schemas[n.name] != nil:
+			return "SCHEMA"
+		case c.users[n.name] != nil:
+			return "USER"
+		case c.roles[n.name] != nil:
+			return "ROLE"
+		case c.connections[n.name] != nil:
+			return "CONNECTION"
+		}
+	}
+	qn, err := x.qualify(n)
+	if err != nil {
+		return ""
+	}
+	t, v, _ := c.object(qn)
+	switch {
+	case t != nil:
+		return "TABLE"
+	case v != nil:
+		return "VIEW"
+	}
+	return ""
+}
+
+func (c *catalog) renameSchema(old, new string) error {
+	s, err := c.schema(old)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.schemas[new]; ok {
+		return errExists(new)
+	}
+	delete(c.schemas, old)
+	s.name = new
+	c.schemas[new] = s
+	for _, other := range c.schemas {
+		for _, t := range other.tables {
+			for _, con := range t.constraints {
+				if con.refSchema == old {
+					con.refSchema = new
+				}
+			}
+		}
+	}
+	for i, p := range c.objPrivs {
+		if p.schema == old {
+			c.objPrivs[i].schema = new
+		}
+		if p.typ == "SCHEMA" && p.name == old {
+			c.objPrivs[i].name = new
+		}
+	}
+	return nil
+}
+
+func (c *catalog) renameObject(x *execContext, kind string, old, new objectName) error {
+	qo, err := x.qualify(old)
+	if err != nil {
+		return err
+	}
+	if new.schema == "" {
+		new.schema = qo.schema
+	}
+	if new.schema != qo.schema {
+		return errorf("renaming %s to another schema is not possible", qo)
+	}
+	s, err := c.schema(qo.schema)
+	if err != nil {
+		return err
+	}
+	if s.objectExists(new.name) {
+		return errExists(new.String())
+	}
+	if kind == "TABLE" {
+		t, ok := s.tables[qo.name]
+		if !ok {
+			return errNotFound(qo.String())
+		}
+		delete(s.tables, qo.name)
+		t.name = new.name
+		s.tables[new.name] = t
+		for _, other := range c.schemas {
+			for _, ot := range other.tables {
+				for _, con := range ot.constraints {
+					if con.refSchema == qo.schema && con.refTable == qo.name {
+						con.refTable = new.name
+					}
+				}
+			}
+		}
+	} else {
+		v, ok := s.views[qo.name]
+		if !ok {
+			return errNotFound(qo.String())
+		}
+		delete(s.views, qo.name)
+		v.name = new.name
+		s.views[new.name] = v
+	}
+	for i, p := range c.objPrivs {
+		if p.schema == qo.schema && p.name == qo.name {
+			c.objPrivs[i].name = new.name
+		}
+	}
+	return nil
+}
+
+func (p *parser) comment() (mutation, error) {
+	err := p.expect("ON")
+	if err != nil {
+		return nil, err
+	}
+	kind, ok := p.acceptAny("SCHEMA", "TABLE", "VIEW", "COLUMN", "USER", "ROLE", "CONNECTION")
+	if !ok {
+		kind = "TABLE"
+	}
+
+	if kind == "COLUMN" {
+		first, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		parts := []string{first}
+		for p.accept(".") {
+			n, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, n)
+		}
+		if len(parts) < 2 {
+			return nil, p.unexpected(".")
+		}
+		err = p.expect("IS")
+		if err != nil {
+			return nil, err
+		}
+		comment, err := p.commentValue()
+		if err != nil {
+			return nil, err
+		}
+		col := parts[len(parts)-1]
+		obj := objectName{name: parts[len(parts)-2]}
+		if len(parts) > 2 {
+			obj.schema = parts[len(parts)-3]
+		}
+		return func(x *execContext, c *catalog) (int, error) {
+			return 0, c.commentColumn(x, obj, col, comment)
+		}, nil
+	}
+
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == "TABLE" && p.accept("(") {
+		comments := map[string]string{}
+		order := []string{}
+		for {
+			col, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			err = p.expect("IS")
+			if err != nil {
+				return nil, err
+			}
+			comment, err := p.commentValue()
+			if err != nil {
+				return nil, err
+			}
+			comments[col] = comment
+			order = append(order, col)
+			if p.accept(")") {
+				break
+			}
+			err = p.expect(",")
+			if err != nil {
+				return nil, err
+			}
+		}
+		return func(x *execContext, c *catalog) (int, error) {
+			for _, col := range order {
+				err := c.commentColumn(x, name, col, comments[col])
+				if err != nil {
+					return 0, err
+				}
+			}
+			return 0, nil
+		}, nil
+	}
+
+	err = p.expect("IS")
+	if err != nil {
+		return nil, err
+	}
+	comment, err := p.commentValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(x *execContext, c *catalog) (int, error) {
+		switch kind {
+		case "SCHEMA":
+			s, err := c.schema(name.name)
+			if err != nil {
+				return 0, err
+			}
+			s.comment = comment
+		case "USER":
+			u, ok := c.users[name.name]
+			if !ok {
+				return 0, errorf("user %s not found", name.name)
+			}
+			u.comment = comment
+		case "ROLE":
+			r, ok := c.roles[name.name]
+			if !ok {
+				return 0, errorf("role %s not found", name.name)
+			}
+			r.comment = comment
+		case "CONNECTION":
+			conn, ok := c.connections[name.name]
+			if !ok {
+				return 0, errorf("connection %s not found", name.name)
+			}
+			conn.comment = comment
+		default:
+			qn, err := x.qualify(name)
+			if err != nil {
+				return 0, err
+			}
+			t, v, err := c.object(qn)
+			if err != nil {
+				return 0, err
+			}
+			if t != nil {
+				t.comment = comment
+			} else {
+				v.comment = comment
+			}
+		}
+		return 0, nil
+	}, nil
+}
+
+// commentValue reads a string or NULL. NULL removes the comment.
+func (p *parser) commentValue() (string, error) {
+	if p.accept("NULL") {
+		return "", nil
+	}
+	return p.stringValue()
+}
+
+func (c *catalog) commentColumn(x *execContext, n objectName, name, comment string) error {
+	qn, err := x.qualify(n)
+	if err != nil {
+		return err
+	}
+	t, v, err := c.object(qn)
+	if err != nil {
+		return err
+	}
+	columns := []*column{}
+	if t != nil {
+		columns = t.columns
+	} else {
+		columns = v.columns
+	}
+	for _, col := range columns {
+		if col.name == name {
+			col.comment = comment
+			return nil
+		}
+	}
+	return errorf("column %s not found", name)
+}
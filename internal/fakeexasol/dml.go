@@ -0,0 +1,345 @@
+package fakeexasol
+
+// coerce converts v to the representation of a column of typ
+func coerce(v interface{}, typ string) (interface{}, error) {
+	v = normalizeValue(v)
+	if v == nil {
+		return nil, nil
+	}
+	return (&castExpr{e: &literal{v: v}, typ: typ}).eval(&env{})
+}
+
+func (p *parser) insert() (mutation, error) {
+	err := p.expect("INTO")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+	var columns []string
+	if p.peek().is("(") && !p.peekN(1).is("SELECT") {
+		columns, err = p.nameList()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var values [][]expr
+	var sub *selectStmt
+	switch {
+	case p.accept("VALUES"):
+		for {
+			err = p.expect("(")
+			if err != nil {
+				return nil, err
+			}
+			row := []expr{}
+			for {
+				if p.accept("DEFAULT") {
+					row = append(row, nil)
+				} else {
+					e, err := p.expression()
+					if err != nil {
+						return nil, err
+					}
+					row = append(row, e)
+				}
+				if p.accept(")") {
+					break
+				}
+				err = p.expect(",")
+				if err != nil {
+					return nil, err
+				}
+			}
+			values = append(values, row)
+			if !p.accept(",") {
+				break
+			}
+		}
+	case p.accept("DEFAULT", "VALUES"):
+		values = [][]expr{{}}
+	default:
+		sub, err = p.query()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		t, err := c.table(qn)
+		if err != nil {
+			return 0, err
+		}
+		targets := make([]int, 0, len(t.columns))
+		if columns == nil {
+			for i := range t.columns {
+				targets = append(targets, i)
+			}
+		} else {
+			for _, col := range columns {
+				i := t.columnIndex(col)
+				if i == -1 {
+					return 0, errorf("column %s not found", col)
+				}
+				targets = append(targets, i)
+			}
+		}
+
+		var rows [][]interface{}
+		if sub != nil {
+			rel, err := sub.run(x, c)
+			if err != nil {
+				return 0, err
+			}
+			rows = rel.rows
+		} else {
+			e := &env{x: x, c: c}
+			for _, exprs := range values {
+				row := make([]interface{}, len(exprs))
+				for i, ex := range exprs {
+					if ex == nil {
+						continue
+					}
+					row[i], err = ex.eval(e)
+					if err != nil {
+						return 0, err
+					}
+				}
+				rows = append(rows, row)
+			}
+		}
+
+		inserted := make([][]interface{}, 0, len(rows))
+		for _, row := range rows {
+			if len(row) != 0 && len(row) != len(targets) {
+				return 0, errorf("number of values does not match number of columns of %s", qn)
+			}
+			full := make([]interface{}, len(t.columns))
+			for i, v := range row {
+				full[targets[i]] = v
+			}
+			err = t.complete(x, c, full, len(t.rows)+len(inserted))
+			if err != nil {
+				return 0, err
+			}
+			inserted = append(inserted, full)
+		}
+		t.rows = append(t.rows, inserted...)
+		return len(inserted), nil
+	}, nil
+}
+
+// complete applies defaults, identities, types and NOT NULL constraints to row
+func (t *tableObject) complete(x *execContext, c *catalog, row []interface{}, position int) error {
+	for i, col := range t.columns {
+		if row[i] == nil && col.identity {
+			row[i] = float64(position + 1)
+		}
+		if row[i] == nil && col.def != "" {
+			p, err := newParser(col.def, nil)
+			if err != nil {
+				return err
+			}
+			e, err := p.expression()
+			if err != nil {
+				return err
+			}
+			row[i], err = e.eval(&env{x: x, c: c})
+			if err != nil {
+				return err
+			}
+		}
+		v, err := coerce(row[i], col.typ)
+		if err != nil {
+			return err
+		}
+		if v == nil && !col.nullable {
+			return &sqlError{
+				code: "27002",
+				text: "constraint violation - not null (" + col.name + " in table " + t.name + ")",
+			}
+		}
+		row[i] = v
+	}
+	return nil
+}
+
+func (t *tableObject) relation() *relation {
+	rel := &relation{}
+	for _, col := range t.columns {
+		rel.columns = append(rel.columns, relColumn{qualifier: t.name, name: col.name, typ: col.typ, nullable: col.nullable})
+	}
+	return rel
+}
+
+func (p *parser) deleteRows() (mutation, error) {
+	p.accept("*")
+	err := p.expect("FROM")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+	var where expr
+	if p.accept("WHERE") {
+		where, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		t, err := c.table(qn)
+		if err != nil {
+			return 0, err
+		}
+		e := &env{x: x, c: c, columns: t.relation().columns}
+		kept := make([][]interface{}, 0, len(t.rows))
+		for _, row := range t.rows {
+			match := true
+			if where != nil {
+				e.row = row
+				match, err = truthy(where, e)
+				if err != nil {
+					return 0, err
+				}
+			}
+			if !match {
+				kept = append(kept, row)
+			}
+		}
+		deleted := len(t.rows) - len(kept)
+		t.rows = kept
+		return deleted, nil
+	}, nil
+}
+
+func (p *parser) update() (mutation, error) {
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind == tokenIdent && !t.is("SET") {
+		p.next()
+	}
+	err = p.expect("SET")
+	if err != nil {
+		return nil, err
+	}
+	columns := []string{}
+	values := []expr{}
+	for {
+		col, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expect("=")
+		if err != nil {
+			return nil, err
+		}
+		e, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+		values = append(values, e)
+		if !p.accept(",") {
+			break
+		}
+	}
+	var where expr
+	if p.accept("WHERE") {
+		where, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		t, err := c.table(qn)
+		if err != nil {
+			return 0, err
+		}
+		targets := make([]int, len(columns))
+		for i, col := range columns {
+			targets[i] = t.columnIndex(col)
+			if targets[i] == -1 {
+				return 0, errorf("column %s not found", col)
+			}
+		}
+		e := &env{x: x, c: c, columns: t.relation().columns}
+		updated := 0
+		for r, row := range t.rows {
+			e.row = row
+			if where != nil {
+				match, err := truthy(where, e)
+				if err != nil {
+					return 0, err
+				}
+				if !match {
+					continue
+				}
+			}
+			n := append([]interface{}{}, row...)
+			for i, ex := range values {
+				v, err := ex.eval(e)
+				if err != nil {
+					return 0, err
+				}
+				col := t.columns[targets[i]]
+				v, err = coerce(v, col.typ)
+				if err != nil {
+					return 0, err
+				}
+				if v == nil && !col.nullable {
+					return 0, &sqlError{
+						code: "27002",
+						text: "constraint violation - not null (" + col.name + " in table " + t.name + ")",
+					}
+				}
+				n[targets[i]] = v
+			}
+			t.rows[r] = n
+			updated++
+		}
+		return updated, nil
+	}, nil
+}
+
+func (p *parser) truncate() (mutation, error) {
+	err := p.expect("TABLE")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.objectName()
+	if err != nil {
+		return nil, err
+	}
+	return func(x *execContext, c *catalog) (int, error) {
+		qn, err := x.qualify(name)
+		if err != nil {
+			return 0, err
+		}
+		t, err := c.table(qn)
+		if err != nil {
+			return 0, err
+		}
+		t.rows = nil
+		return 0, nil
+	}, nil
+}
@@ -0,0 +1,1178 @@
+package fakeexasol
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// env is the row an expression is evaluated against
+type env struct {
+	x       *execContext
+	c       *catalog
+	columns []relColumn
+	row     []interface{}
+	// group holds all rows of the current group for aggregates
+	group [][]interface{}
+}
+
+type expr interface {
+	eval(e *env) (interface{}, error)
+}
+
+type literal struct {
+	v interface{}
+}
+
+type colRef struct {
+	qualifier string
+	name      string
+}
+
+type unaryExpr struct {
+	op string
+	e  expr
+}
+
+type binaryExpr struct {
+	op   string
+	l, r expr
+}
+
+type likeExpr struct {
+	e       expr
+	pattern expr
+	not     bool
+}
+
+type inExpr struct {
+	e    expr
+	list []expr
+	sub  *selectStmt
+	not  bool
+}
+
+type isNullExpr struct {
+	e   expr
+	not bool
+}
+
+type betweenExpr struct {
+	e, lo, hi expr
+	not       bool
+}
+
+type funcExpr struct {
+	name string
+	args []expr
+}
+
+type aggExpr struct {
+	name     string
+	arg      expr
+	star     bool
+	distinct bool
+}
+
+type caseExpr struct {
+	operand expr
+	whens   []expr
+	thens   []expr
+	els     expr
+}
+
+type castExpr struct {
+	e   expr
+	typ string
+}
+
+type subqueryExpr struct {
+	sub *selectStmt
+}
+
+type existsExpr struct {
+	sub *selectStmt
+}
+
+// niladic functions may be used without parenthesis
+var niladic = map[string]bool{
+	"CURRENT_USER":      true,
+	"USER":              true,
+	"CURRENT_SCHEMA":    true,
+	"CURRENT_SESSION":   true,
+	"CURRENT_TIMESTAMP": true,
+	"CURRENT_DATE":      true,
+	"SYSTIMESTAMP":      true,
+	"SYSDATE":           true,
+	"LOCALTIMESTAMP":    true,
+}
+
+var aggregates = map[string]bool{
+	"COUNT": true,
+	"MIN":   true,
+	"MAX":   true,
+	"SUM":   true,
+	"AVG":   true,
+}
+
+// expression parses with precedence OR < AND < NOT < comparison < additive < multiplicative
+func (p *parser) expression() (expr, error) {
+	return p.orExpr()
+}
+
+func (p *parser) orExpr() (expr, error) {
+	l, err := p.andExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("OR") {
+		r, err := p.andExpr()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "OR", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) andExpr() (expr, error) {
+	l, err := p.notExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("AND") {
+		r, err := p.notExpr()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "AND", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) notExpr() (expr, error) {
+	if p.accept("NOT") {
+		e, err := p.notExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "NOT", e: e}, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (expr, error) {
+	if p.accept("EXISTS") {
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+		sub, err := p.query()
+		if err != nil {
+			return nil, err
+		}
+		return &existsExpr{sub: sub}, p.expect(")")
+	}
+
+	l, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if op, ok := p.acceptAny("=", "<>", "!=", "<=", ">=", "<", ">"); ok {
+			r, err := p.additive()
+			if err != nil {
+				return nil, err
+			}
+			if op == "!=" {
+				op = "<>"
+			}
+			l = &binaryExpr{op: op, l: l, r: r}
+			continue
+		}
+		if p.accept("IS") {
+			not := p.accept("NOT")
+			err := p.expect("NULL")
+			if err != nil {
+				return nil, err
+			}
+			l = &isNullExpr{e: l, not: not}
+			continue
+		}
+		not := false
+		if p.peek().is("NOT") && (p.peekN(1).is("LIKE") || p.peekN(1).is("IN") || p.peekN(1).is("BETWEEN")) {
+			p.next()
+			not = true
+		}
+		if p.accept("LIKE") {
+			r, err := p.additive()
+			if err != nil {
+				return nil, err
+			}
+			if p.accept("ESCAPE") {
+				// Only the default escape character is supported
+				_, err = p.additive()
+				if err != nil {
+					return nil, err
+				}
+			}
+			l = &likeExpr{e: l, pattern: r, not: not}
+			continue
+		}
+		if p.accept("IN") {
+			in, err := p.inList(l, not)
+			if err != nil {
+				return nil, err
+			}
+			l = in
+			continue
+		}
+		if p.accept("BETWEEN") {
+			lo, err := p.additive()
+			if err != nil {
+				return nil, err
+			}
+			err = p.expect("AND")
+			if err != nil {
+				return nil, err
+			}
+			hi, err := p.additive()
+			if err != nil {
+				return nil, err
+			}
+			l = &betweenExpr{e: l, lo: lo, hi: hi, not: not}
+			continue
+		}
+		if not {
+			return nil, p.unexpected("LIKE, IN or BETWEEN")
+		}
+		return l, nil
+	}
+}
+
+func (p *parser) inList(l expr, not bool) (expr, error) {
+	err := p.expect("(")
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().is("SELECT") || p.peek().is("WITH") {
+		sub, err := p.query()
+		if err != nil {
+			return nil, err
+		}
+		return &inExpr{e: l, sub: sub, not: not}, p.expect(")")
+	}
+	list := []expr{}
+	for {
+		e, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+		if p.accept(")") {
+			return &inExpr{e: l, list: list, not: not}, nil
+		}
+		err = p.expect(",")
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) additive() (expr, error) {
+	l, err := p.multiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptAny("+", "-", "||")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.multiplicative()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: op, l: l, r: r}
+	}
+}
+
+func (p *parser) multiplicative() (expr, error) {
+	l, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptAny("*", "/")
+		if !ok {
+			return l, nil
+		}
+		r, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: op, l: l, r: r}
+	}
+}
+
+func (p *parser) unary() (expr, error) {
+	if p.accept("-") {
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "-", e: e}, nil
+	}
+	p.accept("+")
+	return p.primary()
+}
+
+func (p *parser) primary() (expr, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenString:
+		p.next()
+		return &literal{v: t.text}, nil
+	case tokenNumber:
+		p.next()
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errorf("invalid number %s", t.text)
+		}
+		return &literal{v: f}, nil
+	case tokenParam:
+		p.next()
+		v, err := p.arg()
+		if err != nil {
+			return nil, err
+		}
+		return &literal{v: normalizeValue(v)}, nil
+	case tokenQuotedIdent:
+		return p.columnRef()
+	case tokenSymbol:
+		if p.accept("(") {
+			if p.peek().is("SELECT") || p.peek().is("WITH") {
+				sub, err := p.query()
+				if err != nil {
+					return nil, err
+				}
+				return &subqueryExpr{sub: sub}, p.expect(")")
+			}
+			e, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expect(")")
+		}
+		if p.accept("*") {
+			return nil, p.unexpected("expression")
+		}
+	case tokenIdent:
+		switch t.text {
+		case "NULL":
+			p.next()
+			return &literal{v: nil}, nil
+		case "TRUE":
+			p.next()
+			return &literal{v: true}, nil
+		case "FALSE":
+			p.next()
+			return &literal{v: false}, nil
+		case "CASE":
+			p.next()
+			return p.caseExpr()
+		case "CAST", "CONVERT":
+			if p.peekN(1).is("(") {
+				p.next()
+				p.next()
+				e, err := p.expression()
+				if err != nil {
+					return nil, err
+				}
+				if !p.accept("AS") {
+					err = p.expect(",")
+					if err != nil {
+						return nil, err
+					}
+				}
+				typ, err := p.dataType()
+				if err != nil {
+					return nil, err
+				}
+				return &castExpr{e: e, typ: typ}, p.expect(")")
+			}
+		}
+		if p.peekN(1).is("(") {
+			return p.function()
+		}
+		return p.columnRef()
+	}
+	return nil, p.unexpected("expression")
+}
+
+func (p *parser) columnRef() (expr, error) {
+	first, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept(".") {
+		second, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept(".") {
+			// schema.table.column
+			third, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			return &colRef{qualifier: second, name: third}, nil
+		}
+		return &colRef{qualifier: first, name: second}, nil
+	}
+	return &colRef{name: first}, nil
+}
+
+func (p *parser) function() (expr, error) {
+	name, _ := p.name()
+	p.next()
+	if aggregates[name] {
+		a := &aggExpr{name: name}
+		if p.accept("*") {
+			a.star = true
+			return a, p.expect(")")
+		}
+		a.distinct = p.accept("DISTINCT")
+		p.accept("ALL")
+		arg, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		a.arg = arg
+		return a, p.expect(")")
+	}
+	f := &funcExpr{name: name}
+	if p.accept(")") {
+		return f, nil
+	}
+	for {
+		arg, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		f.args = append(f.args, arg)
+		if p.accept(")") {
+			return f, nil
+		}
+		err = p.expect(",")
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) caseExpr() (expr, error) {
+	c := &caseExpr{}
+	if !p.peek().is("WHEN") {
+		operand, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		c.operand = operand
+	}
+	for p.accept("WHEN") {
+		w, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expect("THEN")
+		if err != nil {
+			return nil, err
+		}
+		t, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		c.whens = append(c.whens, w)
+		c.thens = append(c.thens, t)
+	}
+	if p.accept("ELSE") {
+		e, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		c.els = e
+	}
+	return c, p.expect("END")
+}
+
+func (l *literal) eval(e *env) (interface{}, error) {
+	return l.v, nil
+}
+
+// lookup returns the index of the column or -1
+func (e *env) lookup(qualifier, name string) int {
+	for i, c := range e.columns {
+		if c.name != name {
+			continue
+		}
+		if qualifier == "" || c.qualifier == qualifier {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *colRef) eval(e *env) (interface{}, error) {
+	i := e.lookup(r.qualifier, r.name)
+	if i != -1 {
+		return e.row[i], nil
+	}
+	if r.qualifier == "" && niladic[r.name] {
+		return (&funcExpr{name: r.name}).eval(e)
+	}
+	if r.qualifier == "" {
+		return nil, errorf("object %s not found", r.name)
+	}
+	return nil, errorf("object %s.%s not found", r.qualifier, r.name)
+}
+
+func (u *unaryExpr) eval(e *env) (interface{}, error) {
+	v, err := u.e.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	switch u.op {
+	case "NOT":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, errorf("data exception - invalid boolean %v", v)
+		}
+		return !b, nil
+	case "-":
+		f, err := toNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		return -f, nil
+	}
+	return nil, errorf("unknown operator %s", u.op)
+}
+
+func (b *binaryExpr) eval(e *env) (interface{}, error) {
+	if b.op == "AND" || b.op == "OR" {
+		return b.evalLogical(e)
+	}
+
+	l, err := b.l.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	r, err := b.r.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.op == "||" {
+		// NULL behaves like the empty string
+		return formatValue(l) + formatValue(r), nil
+	}
+
+	if l == nil || r == nil {
+		return nil, nil
+	}
+
+	switch b.op {
+	case "+", "-", "*", "/":
+		lf, err := toNumber(l)
+		if err != nil {
+			return nil, err
+		}
+		rf, err := toNumber(r)
+		if err != nil {
+			return nil, err
+		}
+		switch b.op {
+		case "+":
+			return lf + rf, nil
+		case "-":
+			return lf - rf, nil
+		case "*":
+			return lf * rf, nil
+		default:
+			if rf == 0 {
+				return nil, &sqlError{code: "22012", text: "data exception - division by zero"}
+			}
+			return lf / rf, nil
+		}
+	}
+
+	cmp, err := compareValues(l, r)
+	if err != nil {
+		return nil, err
+	}
+	switch b.op {
+	case "=":
+		return cmp == 0, nil
+	case "<>":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case ">":
+		return cmp > 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+	return nil, errorf("unknown operator %s", b.op)
+}
+
+func (b *binaryExpr) evalLogical(e *env) (interface{}, error) {
+	l, err := evalBool(b.l, e)
+	if err != nil {
+		return nil, err
+	}
+	if b.op == "AND" && l != nil && !*l {
+		return false, nil
+	}
+	if b.op == "OR" && l != nil && *l {
+		return true, nil
+	}
+	r, err := evalBool(b.r, e)
+	if err != nil {
+		return nil, err
+	}
+	if b.op == "AND" {
+		if r != nil && !*r {
+			return false, nil
+		}
+		if l == nil || r == nil {
+			return nil, nil
+		}
+		return true, nil
+	}
+	if r != nil && *r {
+		return true, nil
+	}
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	return false, nil
+}
+
+func evalBool(x expr, e *env) (*bool, error) {
+	v, err := x.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, errorf("data exception - invalid boolean %v", v)
+	}
+	return &b, nil
+}
+
+// truthy reports whether x evaluates to TRUE. Unknown is false.
+func truthy(x expr, e *env) (bool, error) {
+	if x == nil {
+		return true, nil
+	}
+	b, err := evalBool(x, e)
+	if err != nil || b == nil {
+		return false, err
+	}
+	return *b, nil
+}
+
+func (l *likeExpr) eval(e *env) (interface{}, error) {
+	v, err := l.e.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	p, err := l.pattern.eval(e)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	exp, err := likeRegexp(formatValue(p))
+	if err != nil {
+		return nil, err
+	}
+	return exp.MatchString(formatValue(v)) != l.not, nil
+}
+
+func likeRegexp(pattern string) (*regexp.Regexp, error) {
+	b := &strings.Builder{}
+	b.WriteString("(?s)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func (in *inExpr) eval(e *env) (interface{}, error) {
+	v, err := in.e.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	candidates := []interface{}{}
+	if in.sub != nil {
+		rel, err := in.sub.run(e.x, e.c)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rel.rows {
+			candidates = append(candidates, row[0])
+		}
+	} else {
+		for _, x := range in.list {
+			c, err := x.eval(e)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, c)
+		}
+	}
+	unknown := false
+	for _, c := range candidates {
+		if c == nil {
+			unknown = true
+			continue
+		}
+		cmp, err := compareValues(v, c)
+		if err != nil {
+			return nil, err
+		}
+		if cmp == 0 {
+			return !in.not, nil
+		}
+	}
+	if unknown {
+		return nil, nil
+	}
+	return in.not, nil
+}
+
+func (n *isNullExpr) eval(e *env) (interface{}, error) {
+	v, err := n.e.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	return (v == nil) != n.not, nil
+}
+
+func (b *betweenExpr) eval(e *env) (interface{}, error) {
+	lower, err := (&binaryExpr{op: ">=", l: b.e, r: b.lo}).eval(e)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := (&binaryExpr{op: "<=", l: b.e, r: b.hi}).eval(e)
+	if err != nil {
+		return nil, err
+	}
+	if lower == nil || upper == nil {
+		return nil, nil
+	}
+	return (lower.(bool) && upper.(bool)) != b.not, nil
+}
+
+func (c *caseExpr) eval(e *env) (interface{}, error) {
+	var operand interface{}
+	if c.operand != nil {
+		var err error
+		operand, err = c.operand.eval(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, w := range c.whens {
+		var matched bool
+		if c.operand != nil {
+			v, err := w.eval(e)
+			if err != nil {
+				return nil, err
+			}
+			if operand != nil && v != nil {
+				cmp, err := compareValues(operand, v)
+				if err != nil {
+					return nil, err
+				}
+				matched = cmp == 0
+			}
+		} else {
+			var err error
+			matched, err = truthy(w, e)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if matched {
+			return c.thens[i].eval(e)
+		}
+	}
+	if c.els == nil {
+		return nil, nil
+	}
+	return c.els.eval(e)
+}
+
+func (c *castExpr) eval(e *env) (interface{}, error) {
+	v, err := c.e.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	switch {
+	case strings.HasPrefix(c.typ, "DECIMAL"), c.typ == "DOUBLE":
+		return toNumber(v)
+	case c.typ == "BOOLEAN":
+		switch t := v.(type) {
+		case bool:
+			return t, nil
+		case float64:
+			return t != 0, nil
+		}
+		s := strings.ToUpper(formatValue(v))
+		return s == "TRUE" || s == "1", nil
+	}
+	return formatValue(v), nil
+}
+
+func (s *subqueryExpr) eval(e *env) (interface{}, error) {
+	rel, err := s.sub.run(e.x, e.c)
+	if err != nil {
+		return nil, err
+	}
+	if len(rel.rows) == 0 {
+		return nil, nil
+	}
+	if len(rel.rows) > 1 {
+		return nil, errorf("subquery returned more than one row")
+	}
+	return rel.rows[0][0], nil
+}
+
+func (s *existsExpr) eval(e *env) (interface{}, error) {
+	rel, err := s.sub.run(e.x, e.c)
+	if err != nil {
+		return nil, err
+	}
+	return len(rel.rows) != 0, nil
+}
+
+func (a *aggExpr) eval(e *env) (interface{}, error) {
+	if e.group == nil {
+		return nil, errorf("aggregate %s not allowed here", a.name)
+	}
+	values := []interface{}{}
+	seen := map[string]bool{}
+	for _, row := range e.group {
+		if a.star {
+			values = append(values, true)
+			continue
+		}
+		v, err := a.arg.eval(&env{x: e.x, c: e.c, columns: e.columns, row: row})
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		if a.distinct {
+			key := fmt.Sprintf("%T:%v", v, v)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		values = append(values, v)
+	}
+
+	switch a.name {
+	case "COUNT":
+		return float64(len(values)), nil
+	case "SUM", "AVG":
+		if len(values) == 0 {
+			return nil, nil
+		}
+		sum := 0.0
+		for _, v := range values {
+			f, err := toNumber(v)
+			if err != nil {
+				return nil, err
+			}
+			sum += f
+		}
+		if a.name == "AVG" {
+			return sum / float64(len(values)), nil
+		}
+		return sum, nil
+	}
+
+	// MIN and MAX
+	var result interface{}
+	for _, v := range values {
+		if result == nil {
+			result = v
+			continue
+		}
+		cmp, err := compareValues(v, result)
+		if err != nil {
+			return nil, err
+		}
+		if (a.name == "MIN" && cmp < 0) || (a.name == "MAX" && cmp > 0) {
+			result = v
+		}
+	}
+	return result, nil
+}
+
+func (f *funcExpr) eval(e *env) (interface{}, error) {
+	args := make([]interface{}, 0, len(f.args))
+	for _, a := range f.args {
+		v, err := a.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	arg := func(i int) interface{} {
+		if i < len(args) {
+			return args[i]
+		}
+		return nil
+	}
+
+	switch f.name {
+	case "CURRENT_USER", "USER":
+		return e.x.user, nil
+	case "CURRENT_SCHEMA":
+		if e.x.schema == "" {
+			return nil, nil
+		}
+		return e.x.schema, nil
+	case "CURRENT_SESSION":
+		return float64(e.x.sessionID), nil
+	case "CURRENT_TIMESTAMP", "SYSTIMESTAMP", "LOCALTIMESTAMP", "NOW":
+		return time.Now().UTC().Format(timestampFormat), nil
+	case "CURRENT_DATE", "SYSDATE":
+		return time.Now().UTC().Format("2006-01-02"), nil
+	case "NPROC":
+		return float64(1), nil
+	case "COALESCE", "NVL", "IFNULL":
+		for _, a := range args {
+			if a != nil {
+				return a, nil
+			}
+		}
+		return nil, nil
+	case "NULLIF":
+		if arg(0) == nil || arg(1) == nil {
+			return arg(0), nil
+		}
+		cmp, err := compareValues(arg(0), arg(1))
+		if err != nil {
+			return nil, err
+		}
+		if cmp == 0 {
+			return nil, nil
+		}
+		return arg(0), nil
+	}
+
+	// All other functions return NULL for NULL input
+	for _, a := range args {
+		if a == nil {
+			return nil, nil
+		}
+	}
+
+	switch f.name {
+	case "UPPER", "UCASE":
+		return strings.ToUpper(formatValue(arg(0))), nil
+	case "LOWER", "LCASE":
+		return strings.ToLower(formatValue(arg(0))), nil
+	case "TRIM":
+		return strings.TrimSpace(formatValue(arg(0))), nil
+	case "LTRIM":
+		return strings.TrimLeft(formatValue(arg(0)), " "), nil
+	case "RTRIM":
+		return strings.TrimRight(formatValue(arg(0)), " "), nil
+	case "LENGTH", "LEN", "CHAR_LENGTH", "CHARACTER_LENGTH":
+		return float64(len([]rune(formatValue(arg(0))))), nil
+	case "CONCAT":
+		b := &strings.Builder{}
+		for _, a := range args {
+			b.WriteString(formatValue(a))
+		}
+		return b.String(), nil
+	case "REPLACE":
+		return strings.ReplaceAll(formatValue(arg(0)), formatValue(arg(1)), formatValue(arg(2))), nil
+	case "INSTR", "POSITION", "LOCATE":
+		return float64(strings.Index(formatValue(arg(0)), formatValue(arg(1))) + 1), nil
+	case "SUBSTR", "SUBSTRING", "MID":
+		s := []rune(formatValue(arg(0)))
+		start, err := toNumber(arg(1))
+		if err != nil {
+			return nil, err
+		}
+		from := int(start) - 1
+		if from < 0 {
+			from = 0
+		}
+		if from > len(s) {
+			return "", nil
+		}
+		to := len(s)
+		if len(args) > 2 {
+			n, err := toNumber(arg(2))
+			if err != nil {
+				return nil, err
+			}
+			if from+int(n) < to {
+				to = from + int(n)
+			}
+		}
+		return string(s[from:to]), nil
+	case "TO_CHAR":
+		return formatValue(arg(0)), nil
+	case "TO_NUMBER":
+		return toNumber(arg(0))
+	case "ABS":
+		n, err := toNumber(arg(0))
+		if err != nil {
+			return nil, err
+		}
+		return math.Abs(n), nil
+	case "ROUND":
+		n, err := toNumber(arg(0))
+		if err != nil {
+			return nil, err
+		}
+		return math.Round(n), nil
+	}
+	return nil, errorf("function %s not supported", f.name)
+}
+
+// normalizeValue converts bind parameters from the wire
+// into the value types of the engine
+func normalizeValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case int:
+		return float64(t)
+	case int64:
+		return float64(t)
+	case int32:
+		return float64(t)
+	}
+	return v
+}
+
+func toNumber(v interface{}) (float64, error) {
+	switch t := v.(type) {
+	case float64:
+		return t, nil
+	case bool:
+		if t {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		if err != nil {
+			return 0, &sqlError{code: "22018", text: fmt.Sprintf("data exception - invalid character value for cast; Value: '%s'", t)}
+		}
+		return f, nil
+	}
+	return 0, errorf("data exception - cannot convert %v to number", v)
+}
+
+// compareValues orders non NULL values. Numbers compare with
+// strings if the string is a number.
+func compareValues(a, b interface{}) (int, error) {
+	switch at := a.(type) {
+	case float64:
+		bf, err := toNumber(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareFloat(at, bf), nil
+	case bool:
+		switch bt := b.(type) {
+		case bool:
+			if at == bt {
+				return 0, nil
+			}
+			if !at {
+				return -1, nil
+			}
+			return 1, nil
+		case string:
+			return strings.Compare(strings.ToUpper(formatValue(at)), strings.ToUpper(bt)), nil
+		}
+		af, _ := toNumber(at)
+		bf, err := toNumber(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareFloat(af, bf), nil
+	case string:
+		switch bt := b.(type) {
+		case float64:
+			af, err := toNumber(at)
+			if err != nil {
+				return 0, err
+			}
+			return compareFloat(af, bt), nil
+		case bool:
+			return strings.Compare(strings.ToUpper(at), strings.ToUpper(formatValue(bt))), nil
+		}
+		return strings.Compare(at, formatValue(b)), nil
+	}
+	return strings.Compare(formatValue(a), formatValue(b)), nil
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// formatValue renders a value as string. NULL is the empty string.
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		if t {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,150 @@
+package fakeexasol
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenParam
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	// text is uppercased for unquoted identifiers and unescaped
+	// for quoted identifiers and strings
+	text string
+	// pos is the byte offset in the statement
+	pos int
+	end int
+}
+
+// is reports whether t is the unquoted keyword or symbol s
+func (t token) is(s string) bool {
+	return (t.kind == tokenIdent || t.kind == tokenSymbol) && t.text == s
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of statement"
+	}
+	return t.text
+}
+
+var symbols = []string{"<>", "!=", "<=", ">=", "||", "(", ")", ",", ".", ";", "=", "<", ">", "*", "+", "-", "/"}
+
+// lex splits a statement into tokens. Comments are dropped.
+func lex(stmt string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(stmt); {
+		c := stmt[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(stmt[i:], "--"):
+			end := strings.IndexByte(stmt[i:], '\n')
+			if end == -1 {
+				i = len(stmt)
+			} else {
+				i += end
+			}
+		case strings.HasPrefix(stmt[i:], "/*"):
+			end := strings.Index(stmt[i+2:], "*/")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated comment at %d", i)
+			}
+			i += end + 4
+		case c == '\'' || c == '"':
+			text, end, err := lexQuoted(stmt, i)
+			if err != nil {
+				return nil, err
+			}
+			kind := tokenString
+			if c == '"' {
+				kind = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: i, end: end})
+			i = end
+		case c == '?':
+			tokens = append(tokens, token{kind: tokenParam, text: "?", pos: i, end: i + 1})
+			i++
+		case c >= '0' && c <= '9':
+			end := i
+			for end < len(stmt) && (stmt[end] >= '0' && stmt[end] <= '9' || stmt[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: stmt[i:end], pos: i, end: end})
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)) || c >= 0x80:
+			end := i
+			for end < len(stmt) {
+				r := rune(stmt[end])
+				if r != '_' && r != '#' && r != '$' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r < 0x80 {
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: strings.ToUpper(stmt[i:end]), pos: i, end: end})
+			i = end
+		default:
+			matched := false
+			for _, s := range symbols {
+				if strings.HasPrefix(stmt[i:], s) {
+					tokens = append(tokens, token{kind: tokenSymbol, text: s, pos: i, end: i + len(s)})
+					i += len(s)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(stmt), end: len(stmt)})
+	return tokens, nil
+}
+
+// lexQuoted reads a quoted string starting at i. Doubled quotes
+// are unescaped.
+func lexQuoted(stmt string, i int) (string, int, error) {
+	q := stmt[i]
+	b := &strings.Builder{}
+	for j := i + 1; j < len(stmt); j++ {
+		if stmt[j] != q {
+			b.WriteByte(stmt[j])
+			continue
+		}
+		if j+1 < len(stmt) && stmt[j+1] == q {
+			b.WriteByte(q)
+			j++
+			continue
+		}
+		return b.String(), j + 1, nil
+	}
+	return "", 0, fmt.Errorf("unterminated quote at %d", i)
+}
+
+// countParams counts the ? placeholders of a statement
+func countParams(stmt string) (int, error) {
+	tokens, err := lex(stmt)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, t := range tokens {
+		if t.kind == tokenParam {
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,238 @@
+package fakeexasol
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sqlError is reported back to the client as exception
+type sqlError struct {
+	code string
+	text string
+}
+
+func (err *sqlError) Error() string {
+	return fmt.Sprintf("%s (%s)", err.text, err.code)
+}
+
+func errorf(format string, args ...interface{}) error {
+	return &sqlError{
+		code: "42000",
+		text: fmt.Sprintf(format, args...),
+	}
+}
+
+func errNotFound(name string) error {
+	return errorf("object %s not found", name)
+}
+
+func errExists(name string) error {
+	return &sqlError{
+		code: "42500",
+		text: fmt.Sprintf("object %s already exists", name),
+	}
+}
+
+// errCollision matches the message of Exasol so the retry logic
+// of the provider kicks in
+var errCollision = &sqlError{
+	code: "40001",
+	text: "GlobalTransactionRollback msg: Transaction collision: automatic transaction rollback.",
+}
+
+// objectName is an optionally schema qualified name
+type objectName struct {
+	schema string
+	name   string
+}
+
+func (n objectName) String() string {
+	if n.schema == "" {
+		return n.name
+	}
+	return n.schema + "." + n.name
+}
+
+// parser consumes tokens of a single statement. Bind parameters
+// are resolved while parsing.
+type parser struct {
+	sql     string
+	tokens  []token
+	i       int
+	args    []interface{}
+	nextArg int
+}
+
+func newParser(stmt string, args []interface{}) (*parser, error) {
+	tokens, err := lex(stmt)
+	if err != nil {
+		return nil, errorf("syntax error: %s", err)
+	}
+	return &parser{
+		sql:    stmt,
+		tokens: tokens,
+		args:   args,
+	}, nil
+}
+
+func (p *parser) peek() token {
+	return p.peekN(0)
+}
+
+func (p *parser) peekN(n int) token {
+	if p.i+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.i+n]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the keywords or symbols if all of them follow
+func (p *parser) accept(words ...string) bool {
+	for n, w := range words {
+		if !p.peekN(n).is(w) {
+			return false
+		}
+	}
+	p.i += len(words)
+	return true
+}
+
+// acceptAny consumes one of the keywords and returns it
+func (p *parser) acceptAny(words ...string) (string, bool) {
+	for _, w := range words {
+		if p.accept(w) {
+			return w, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(words ...string) error {
+	if p.accept(words...) {
+		return nil
+	}
+	return p.unexpected(strings.Join(words, " "))
+}
+
+func (p *parser) unexpected(expected string) error {
+	t := p.peek()
+	return errorf("syntax error, unexpected %s, expecting %s [line 1, column %d]", t, expected, t.pos+1)
+}
+
+// name reads an identifier. Unquoted identifiers are uppercase.
+func (p *parser) name() (string, error) {
+	t := p.peek()
+	if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+		return "", p.unexpected("identifier")
+	}
+	p.i++
+	return t.text, nil
+}
+
+// objectName reads [schema.]name
+func (p *parser) objectName() (objectName, error) {
+	first, err := p.name()
+	if err != nil {
+		return objectName{}, err
+	}
+	if !p.accept(".") {
+		return objectName{name: first}, nil
+	}
+	second, err := p.name()
+	if err != nil {
+		return objectName{}, err
+	}
+	return objectName{schema: first, name: second}, nil
+}
+
+// names reads a comma separated list of identifiers in parenthesis
+func (p *parser) nameList() ([]string, error) {
+	err := p.expect("(")
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for {
+		n, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, n)
+		if p.accept(")") {
+			return names, nil
+		}
+		err = p.expect(",")
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// stringValue reads a string literal or bind parameter
+func (p *parser) stringValue() (string, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenString:
+		p.i++
+		return t.text, nil
+	case tokenParam:
+		p.i++
+		v, err := p.arg()
+		if err != nil {
+			return "", err
+		}
+		return formatValue(v), nil
+	}
+	return "", p.unexpected("string")
+}
+
+// optionalComment reads COMMENT IS '...'
+func (p *parser) optionalComment() (*string, error) {
+	if !p.accept("COMMENT", "IS") {
+		return nil, nil
+	}
+	if p.accept("NULL") {
+		empty := ""
+		return &empty, nil
+	}
+	c, err := p.stringValue()
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (p *parser) arg() (interface{}, error) {
+	if p.nextArg >= len(p.args) {
+		return nil, errorf("missing value for parameter %d", p.nextArg+1)
+	}
+	v := p.args[p.nextArg]
+	p.nextArg++
+	return v, nil
+}
+
+// rawUntil returns the raw statement text from token from up to
+// (excluding) token to
+func (p *parser) raw(from, to int) string {
+	if from >= to {
+		return ""
+	}
+	return strings.TrimSpace(p.sql[p.tokens[from].pos:p.tokens[to-1].end])
+}
+
+// done ensures that the whole statement was consumed
+func (p *parser) done() error {
+	p.accept(";")
+	if p.peek().kind != tokenEOF {
+		return p.unexpected("end of statement")
+	}
+	return nil
+}
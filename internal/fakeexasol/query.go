@@ -0,0 +1,819 @@
+package fakeexasol
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxViewDepth guards against Views referencing themselves
+	maxViewDepth = 32
+)
+
+type relColumn struct {
+	qualifier string
+	name      string
+	typ       string
+	nullable  bool
+}
+
+// relation is a materialized result
+type relation struct {
+	columns []relColumn
+	rows    [][]interface{}
+}
+
+type selectItem struct {
+	e    expr
+	text string
+	// alias is empty if none is given
+	alias         string
+	star          bool
+	starQualifier string
+}
+
+type tableRef struct {
+	name  objectName
+	sub   *selectStmt
+	alias string
+}
+
+type joinClause struct {
+	kind string
+	ref  tableRef
+	on   expr
+}
+
+type fromItem struct {
+	ref   tableRef
+	joins []joinClause
+}
+
+type orderItem struct {
+	e     expr
+	desc  bool
+	nulls string
+}
+
+// selectCore is a single SELECT without set operations
+type selectCore struct {
+	distinct bool
+	items    []selectItem
+	from     []fromItem
+	where    expr
+	groupBy  []expr
+	having   expr
+}
+
+type selectStmt struct {
+	cores []*selectCore
+	// unionAll per core after the first
+	unionAll []bool
+	orderBy  []orderItem
+	limit    int
+	offset   int
+}
+
+// query parses SELECT ... [UNION [ALL] SELECT ...] [ORDER BY ...] [LIMIT ...]
+func (p *parser) query() (*selectStmt, error) {
+	if p.peek().is("WITH") {
+		return nil, errorf("WITH clauses are not supported")
+	}
+	s := &selectStmt{limit: -1}
+	for {
+		wrapped := p.accept("(")
+		core, err := p.selectCore()
+		if err != nil {
+			return nil, err
+		}
+		if wrapped {
+			err = p.expect(")")
+			if err != nil {
+				return nil, err
+			}
+		}
+		s.cores = append(s.cores, core)
+		if !p.accept("UNION") {
+			break
+		}
+		s.unionAll = append(s.unionAll, p.accept("ALL"))
+	}
+
+	if p.accept("ORDER", "BY") {
+		for {
+			e, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			item := orderItem{e: e}
+			if p.accept("DESC") {
+				item.desc = true
+			} else {
+				p.accept("ASC")
+			}
+			if p.accept("NULLS") {
+				item.nulls, _ = p.acceptAny("FIRST", "LAST")
+			}
+			s.orderBy = append(s.orderBy, item)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.accept("LIMIT") {
+		first, err := p.intValue()
+		if err != nil {
+			return nil, err
+		}
+		s.limit = first
+		if p.accept(",") {
+			second, err := p.intValue()
+			if err != nil {
+				return nil, err
+			}
+			s.offset, s.limit = first, second
+		} else if p.accept("OFFSET") {
+			s.offset, err = p.intValue()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+func (p *parser) intValue() (int, error) {
+	e, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+	v, err := e.eval(&env{})
+	if err != nil {
+		return 0, err
+	}
+	f, err := toNumber(v)
+	return int(f), err
+}
+
+func (p *parser) selectCore() (*selectCore, error) {
+	err := p.expect("SELECT")
+	if err != nil {
+		return nil, err
+	}
+	c := &selectCore{}
+	c.distinct = p.accept("DISTINCT")
+	p.accept("ALL")
+
+	for {
+		item, err := p.selectItem()
+		if err != nil {
+			return nil, err
+		}
+		c.items = append(c.items, item)
+		if !p.accept(",") {
+			break
+		}
+	}
+
+	if p.accept("FROM") {
+		for {
+			f, err := p.fromItem()
+			if err != nil {
+				return nil, err
+			}
+			c.from = append(c.from, f)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.accept("WHERE") {
+		c.where, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.accept("GROUP", "BY") {
+		for {
+			e, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			c.groupBy = append(c.groupBy, e)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.accept("HAVING") {
+		c.having, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (p *parser) selectItem() (selectItem, error) {
+	if p.accept("*") {
+		return selectItem{star: true}, nil
+	}
+	if (p.peek().kind == tokenIdent || p.peek().kind == tokenQuotedIdent) && p.peekN(1).is(".") && p.peekN(2).is("*") {
+		q, _ := p.name()
+		p.next()
+		p.next()
+		return selectItem{star: true, starQualifier: q}, nil
+	}
+
+	start := p.i
+	e, err := p.expression()
+	if err != nil {
+		return selectItem{}, err
+	}
+	item := selectItem{e: e, text: strings.ToUpper(p.raw(start, p.i))}
+	if ref, ok := e.(*colRef); ok {
+		item.text = ref.name
+	}
+	if p.accept("AS") {
+		item.alias, err = p.name()
+		if err != nil {
+			return selectItem{}, err
+		}
+	} else if p.peek().kind == tokenQuotedIdent || (p.peek().kind == tokenIdent && !reserved[p.peek().text]) {
+		item.alias, _ = p.name()
+	}
+	return item, nil
+}
+
+// reserved words end expressions where an alias could follow
+var reserved = map[string]bool{
+	"FROM": true, "WHERE": true, "GROUP": true, "HAVING": true, "ORDER": true,
+	"LIMIT": true, "UNION": true, "JOIN": true, "INNER": true, "LEFT": true,
+	"RIGHT": true, "FULL": true, "OUTER": true, "CROSS": true, "ON": true,
+	"COMMENT": true, "OFFSET": true, "WITH": true, "AND": true, "OR": true,
+}
+
+func (p *parser) tableRef() (tableRef, error) {
+	ref := tableRef{}
+	if p.accept("(") {
+		sub, err := p.query()
+		if err != nil {
+			return ref, err
+		}
+		err = p.expect(")")
+		if err != nil {
+			return ref, err
+		}
+		ref.sub = sub
+	} else {
+		n, err := p.objectName()
+		if err != nil {
+			return ref, err
+		}
+		ref.name = n
+	}
+	if p.accept("AS") {
+		alias, err := p.name()
+		if err != nil {
+			return ref, err
+		}
+		ref.alias = alias
+	} else if p.peek().kind == tokenQuotedIdent || (p.peek().kind == tokenIdent && !reserved[p.peek().text]) {
+		ref.alias, _ = p.name()
+	}
+	return ref, nil
+}
+
+func (p *parser) fromItem() (fromItem, error) {
+	ref, err := p.tableRef()
+	if err != nil {
+		return fromItem{}, err
+	}
+	f := fromItem{ref: ref}
+	for {
+		kind := ""
+		switch {
+		case p.accept("JOIN"), p.accept("INNER", "JOIN"):
+			kind = "INNER"
+		case p.accept("LEFT", "JOIN"), p.accept("LEFT", "OUTER", "JOIN"):
+			kind = "LEFT"
+		case p.accept("RIGHT", "JOIN"), p.accept("RIGHT", "OUTER", "JOIN"):
+			kind = "RIGHT"
+		case p.accept("CROSS", "JOIN"):
+			kind = "CROSS"
+		default:
+			return f, nil
+		}
+		jref, err := p.tableRef()
+		if err != nil {
+			return fromItem{}, err
+		}
+		j := joinClause{kind: kind, ref: jref}
+		if kind != "CROSS" {
+			err = p.expect("ON")
+			if err != nil {
+				return fromItem{}, err
+			}
+			j.on, err = p.expression()
+			if err != nil {
+				return fromItem{}, err
+			}
+		}
+		f.joins = append(f.joins, j)
+	}
+}
+
+// run executes the query against c
+func (s *selectStmt) run(x *execContext, c *catalog) (*relation, error) {
+	if len(s.cores) == 1 {
+		return s.cores[0].run(x, c, s.orderBy, s.limit, s.offset)
+	}
+
+	var result *relation
+	for i, core := range s.cores {
+		rel, err := core.run(x, c, nil, -1, 0)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = rel
+			continue
+		}
+		if len(rel.columns) != len(result.columns) {
+			return nil, errorf("UNION requires the same number of columns")
+		}
+		result.rows = append(result.rows, rel.rows...)
+		if !s.unionAll[i-1] {
+			result.rows = distinctRows(result.rows)
+		}
+	}
+
+	// ORDER BY of set operations only sees the result columns
+	err := sortRows(x, c, result.columns, result.rows, result.rows, s.orderBy)
+	if err != nil {
+		return nil, err
+	}
+	result.rows = limitRows(result.rows, s.limit, s.offset)
+	return result, nil
+}
+
+func (core *selectCore) run(x *execContext, c *catalog, orderBy []orderItem, limit, offset int) (*relation, error) {
+	source, err := core.source(x, c)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]interface{}{}
+	for _, row := range source.rows {
+		ok, err := truthy(core.where, &env{x: x, c: c, columns: source.columns, row: row})
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+
+	out := &relation{}
+	for _, item := range core.items {
+		if item.star {
+			for _, col := range source.columns {
+				if item.starQualifier == "" || col.qualifier == item.starQualifier {
+					out.columns = append(out.columns, relColumn{name: col.name, typ: col.typ, nullable: col.nullable})
+				}
+			}
+			continue
+		}
+		name := item.alias
+		if name == "" {
+			name = item.text
+		}
+		col := relColumn{name: name, nullable: true}
+		if ref, ok := item.e.(*colRef); ok {
+			if i := (&env{columns: source.columns}).lookup(ref.qualifier, ref.name); i != -1 {
+				col.typ = source.columns[i].typ
+				col.nullable = source.columns[i].nullable
+			}
+		}
+		out.columns = append(out.columns, col)
+	}
+
+	// Every group is represented by its first row for
+	// non aggregated expressions
+	groups := [][][]interface{}{}
+	grouped := len(core.groupBy) > 0 || core.hasAggregate()
+	if grouped {
+		groups, err = core.group(x, c, source.columns, rows)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, row := range rows {
+			groups = append(groups, [][]interface{}{row})
+		}
+	}
+
+	// sortEnvs keep the source row next to the result for ORDER BY
+	sourceRows := [][]interface{}{}
+	for _, g := range groups {
+		var first []interface{}
+		if len(g) > 0 {
+			first = g[0]
+		} else {
+			first = make([]interface{}, len(source.columns))
+		}
+		e := &env{x: x, c: c, columns: source.columns, row: first}
+		if grouped {
+			e.group = g
+		}
+		if core.having != nil {
+			ok, err := truthy(core.having, e)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		result := []interface{}{}
+		for _, item := range core.items {
+			if item.star {
+				for i, col := range source.columns {
+					if item.starQualifier == "" || col.qualifier == item.starQualifier {
+						result = append(result, first[i])
+					}
+				}
+				continue
+			}
+			v, err := item.e.eval(e)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, v)
+		}
+		out.rows = append(out.rows, result)
+		sourceRows = append(sourceRows, first)
+	}
+
+	for i, col := range out.columns {
+		if col.typ != "" {
+			continue
+		}
+		out.columns[i].typ = "VARCHAR(2000000) UTF8"
+		for _, row := range out.rows {
+			if row[i] != nil {
+				out.columns[i].typ = typeOfValue(row[i])
+				break
+			}
+		}
+	}
+
+	if core.distinct {
+		out.rows = distinctRows(out.rows)
+		sourceRows = out.rows
+		err = sortRows(x, c, out.columns, out.rows, sourceRows, orderBy)
+	} else {
+		err = sortRowsWithSource(x, c, out, source.columns, sourceRows, orderBy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out.rows = limitRows(out.rows, limit, offset)
+	return out, nil
+}
+
+func (core *selectCore) hasAggregate() bool {
+	for _, item := range core.items {
+		if item.e != nil && containsAggregate(item.e) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAggregate(e expr) bool {
+	switch t := e.(type) {
+	case *aggExpr:
+		return true
+	case *unaryExpr:
+		return containsAggregate(t.e)
+	case *binaryExpr:
+		return containsAggregate(t.l) || containsAggregate(t.r)
+	case *funcExpr:
+		for _, a := range t.args {
+			if containsAggregate(a) {
+				return true
+			}
+		}
+	case *castExpr:
+		return containsAggregate(t.e)
+	case *caseExpr:
+		for _, w := range t.thens {
+			if containsAggregate(w) {
+				return true
+			}
+		}
+		if t.els != nil {
+			return containsAggregate(t.els)
+		}
+	}
+	return false
+}
+
+func (core *selectCore) group(x *execContext, c *catalog, columns []relColumn, rows [][]interface{}) ([][][]interface{}, error) {
+	if len(core.groupBy) == 0 {
+		// Aggregates without GROUP BY always return a single row
+		return [][][]interface{}{rows}, nil
+	}
+	keys := []string{}
+	groups := map[string][][]interface{}{}
+	for _, row := range rows {
+		parts := []string{}
+		for _, g := range core.groupBy {
+			v, err := g.eval(&env{x: x, c: c, columns: columns, row: row})
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, fmt.Sprintf("%T:%v", v, v))
+		}
+		key := strings.Join(parts, "\x00")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+	result := make([][][]interface{}, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, groups[k])
+	}
+	return result, nil
+}
+
+// source builds the joined relation of the FROM clause
+func (core *selectCore) source(x *execContext, c *catalog) (*relation, error) {
+	if len(core.from) == 0 {
+		return dual(), nil
+	}
+	var result *relation
+	for _, f := range core.from {
+		rel, err := f.ref.load(x, c)
+		if err != nil {
+			return nil, err
+		}
+		for _, j := range f.joins {
+			right, err := j.ref.load(x, c)
+			if err != nil {
+				return nil, err
+			}
+			rel, err = join(x, c, rel, right, j.kind, j.on)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if result == nil {
+			result = rel
+			continue
+		}
+		result, err = join(x, c, result, rel, "CROSS", nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func dual() *relation {
+	return &relation{
+		rows: [][]interface{}{{}},
+	}
+}
+
+func join(x *execContext, c *catalog, left, right *relation, kind string, on expr) (*relation, error) {
+	columns := append(append([]relColumn{}, left.columns...), right.columns...)
+	result := &relation{columns: columns}
+	rightMatched := make([]bool, len(right.rows))
+	for _, l := range left.rows {
+		matched := false
+		for ri, r := range right.rows {
+			row := append(append([]interface{}{}, l...), r...)
+			ok, err := truthy(on, &env{x: x, c: c, columns: columns, row: row})
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matched = true
+				rightMatched[ri] = true
+				result.rows = append(result.rows, row)
+			}
+		}
+		if !matched && kind == "LEFT" {
+			row := append(append([]interface{}{}, l...), make([]interface{}, len(right.columns))...)
+			result.rows = append(result.rows, row)
+		}
+	}
+	if kind == "RIGHT" {
+		for ri, r := range right.rows {
+			if !rightMatched[ri] {
+				row := append(make([]interface{}, len(left.columns)), r...)
+				result.rows = append(result.rows, row)
+			}
+		}
+	}
+	return result, nil
+}
+
+// load materializes the referenced Table, View, system view or subquery
+func (ref *tableRef) load(x *execContext, c *catalog) (*relation, error) {
+	var rel *relation
+	var err error
+	qualifier := ref.alias
+	if ref.sub != nil {
+		rel, err = ref.sub.run(x, c)
+	} else {
+		if qualifier == "" {
+			qualifier = ref.name.name
+		}
+		rel, err = loadObject(x, c, ref.name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]relColumn, len(rel.columns))
+	for i, col := range rel.columns {
+		col.qualifier = qualifier
+		columns[i] = col
+	}
+	return &relation{columns: columns, rows: rel.rows}, nil
+}
+
+func loadObject(x *execContext, c *catalog, n objectName) (*relation, error) {
+	if n.schema == "" && n.name == "DUAL" {
+		return dual(), nil
+	}
+	if n.schema == "SYS" || (n.schema == "" && strings.HasPrefix(n.name, "EXA_")) {
+		if v, ok := sysViews[n.name]; ok {
+			return v(x, c), nil
+		}
+		if n.schema == "SYS" {
+			return nil, errNotFound(n.String())
+		}
+	}
+
+	qn, err := x.qualify(n)
+	if err != nil {
+		return nil, err
+	}
+	t, v, err := c.object(qn)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		rel := &relation{}
+		for _, col := range t.columns {
+			rel.columns = append(rel.columns, relColumn{name: col.name, typ: col.typ, nullable: col.nullable})
+		}
+		for _, row := range t.rows {
+			rel.rows = append(rel.rows, append([]interface{}{}, row...))
+		}
+		return rel, nil
+	}
+	return v.run(x, c)
+}
+
+// run executes the subquery of the View
+func (v *viewObject) run(x *execContext, c *catalog) (*relation, error) {
+	if x.depth > maxViewDepth {
+		return nil, errorf("view %s is recursive", v.name)
+	}
+	p, err := newParser(v.subquery, nil)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := p.query()
+	if err != nil {
+		return nil, err
+	}
+	inner := *x
+	inner.depth++
+	inner.schema = v.scope
+	rel, err := sub.run(&inner, c)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rel.columns {
+		if i < len(v.columns) {
+			rel.columns[i].name = v.columns[i].name
+		}
+	}
+	return rel, nil
+}
+
+func distinctRows(rows [][]interface{}) [][]interface{} {
+	seen := map[string]bool{}
+	result := [][]interface{}{}
+	for _, row := range rows {
+		key := fmt.Sprintf("%#v", row)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, row)
+	}
+	return result
+}
+
+func limitRows(rows [][]interface{}, limit, offset int) [][]interface{} {
+	if offset > len(rows) {
+		offset = len(rows)
+	}
+	rows = rows[offset:]
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+func sortRows(x *execContext, c *catalog, columns []relColumn, rows, sourceRows [][]interface{}, orderBy []orderItem) error {
+	return sortRowsWithSource(x, c, &relation{columns: columns, rows: rows}, nil, sourceRows, orderBy)
+}
+
+// sortRowsWithSource orders out.rows. ORDER BY expressions may refer
+// to result columns by alias or position and to source columns.
+func sortRowsWithSource(x *execContext, c *catalog, out *relation, sourceColumns []relColumn, sourceRows [][]interface{}, orderBy []orderItem) error {
+	if len(orderBy) == 0 {
+		return nil
+	}
+	columns := append(append([]relColumn{}, out.columns...), sourceColumns...)
+
+	keys := make([][]interface{}, len(out.rows))
+	for i, row := range out.rows {
+		combined := append([]interface{}{}, row...)
+		if sourceColumns != nil {
+			combined = append(combined, sourceRows[i]...)
+		}
+		e := &env{x: x, c: c, columns: columns, row: combined}
+		for _, o := range orderBy {
+			if l, ok := o.e.(*literal); ok {
+				if pos, ok := l.v.(float64); ok && int(pos) >= 1 && int(pos) <= len(row) {
+					keys[i] = append(keys[i], row[int(pos)-1])
+					continue
+				}
+			}
+			v, err := o.e.eval(e)
+			if err != nil {
+				return err
+			}
+			keys[i] = append(keys[i], v)
+		}
+	}
+
+	indices := make([]int, len(out.rows))
+	for i := range indices {
+		indices[i] = i
+	}
+	var sortErr error
+	sort.SliceStable(indices, func(a, b int) bool {
+		ka, kb := keys[indices[a]], keys[indices[b]]
+		for k, o := range orderBy {
+			va, vb := ka[k], kb[k]
+			if va == nil || vb == nil {
+				if va == nil && vb == nil {
+					continue
+				}
+				// NULLs are last in ascending order by default
+				nullsFirst := o.desc
+				if o.nulls != "" {
+					nullsFirst = o.nulls == "FIRST"
+				}
+				return (va == nil) == nullsFirst
+			}
+			cmp, err := compareValues(va, vb)
+			if err != nil {
+				sortErr = err
+				return false
+			}
+			if cmp == 0 {
+				continue
+			}
+			if o.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+	if sortErr != nil {
+		return sortErr
+	}
+	sorted := make([][]interface{}, len(out.rows))
+	for i, idx := range indices {
+		sorted[i] = out.rows[idx]
+	}
+	copy(out.rows, sorted)
+	return nil
+}
@@ -0,0 +1,602 @@
+package fakeexasol
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// DefaultUser is the DBA user every Server starts with
+	DefaultUser = "SYS"
+	// DefaultPassword is the password of DefaultUser
+	DefaultPassword = "exasol"
+
+	// maxInlineRows is the number of rows sent without result set handle
+	maxInlineRows = 1000
+	// fetchRows is the number of rows sent per fetch
+	fetchRows = 1000
+)
+
+// Server is an in-process stand-in for Exasol speaking the
+// Websocket JSON protocol. Its catalog lives in memory only.
+type Server struct {
+	srv      *httptest.Server
+	key      *rsa.PrivateKey
+	upgrader websocket.Upgrader
+
+	mu      sync.Mutex
+	catalog *catalog
+	// version is increased with every commit so that sessions
+	// can tell whether their snapshot is still current
+	version       int
+	nextSessionID int
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultServer *Server
+)
+
+// Default returns a Server shared by the whole process. It is started
+// on first use and never closed.
+func Default() *Server {
+	defaultOnce.Do(func() {
+		defaultServer = NewServer()
+	})
+	return defaultServer
+}
+
+// NewServer starts a Server listening on a random local port
+func NewServer() *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		panic(err)
+	}
+	s := &Server{
+		key:           key,
+		catalog:       newCatalog(DefaultUser, DefaultPassword),
+		nextSessionID: 1,
+	}
+	s.srv = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Host is the address to pass as host in the DSN
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.srv.Listener.Addr().String())
+	return host
+}
+
+// Port is the port to pass in the DSN
+func (s *Server) Port() int {
+	_, port, _ := net.SplitHostPort(s.srv.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return p
+}
+
+// Close shuts down the Server and all sessions
+func (s *Server) Close() {
+	s.srv.CloseClientConnections()
+	s.srv.Close()
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	s.mu.Lock()
+	id := s.nextSessionID
+	s.nextSessionID++
+	s.mu.Unlock()
+
+	sess := &session{
+		id:         id,
+		server:     s,
+		ws:         ws,
+		params:     map[string]string{},
+		autocommit: true,
+		statements: map[int]string{},
+		resultSets: map[int]*relation{},
+	}
+	sess.serve()
+}
+
+// session is the state of a single Websocket connection
+type session struct {
+	id     int
+	server *Server
+	ws     *websocket.Conn
+
+	authenticated bool
+	loggingIn     bool
+	compression   bool
+
+	user       string
+	schema     string
+	params     map[string]string
+	autocommit bool
+
+	// tx is the private snapshot of an open transaction or nil
+	tx *catalog
+	// txVersion is the Server version tx was cloned from
+	txVersion int
+	// journal records the mutations of tx for replay on commit
+	journal []journalEntry
+
+	nextHandle int
+	statements map[int]string
+	resultSets map[int]*relation
+}
+
+type journalEntry struct {
+	x      execContext
+	mutate mutation
+}
+
+type response struct {
+	Status       string             `json:"status"`
+	ResponseData interface{}        `json:"responseData,omitempty"`
+	Exception    *exasol.Exception  `json:"exception,omitempty"`
+	Attributes   *exasol.Attributes `json:"attributes,omitempty"`
+}
+
+type rowCountResult struct {
+	ResultType string `json:"resultType"`
+	RowCount   int    `json:"rowCount"`
+}
+
+type resultSetResult struct {
+	ResultType string                               `json:"resultType"`
+	ResultSet  exasol.SQLQueryResponseResultSetData `json:"resultSet"`
+}
+
+type results struct {
+	NumResults int           `json:"numResults"`
+	Results    []interface{} `json:"results"`
+}
+
+// request is the union of all commands the driver sends
+type request struct {
+	Command          string            `json:"command"`
+	SQLText          string            `json:"sqlText"`
+	StatementHandle  int               `json:"statementHandle"`
+	ResultSetHandle  int               `json:"resultSetHandle"`
+	ResultSetHandles []int             `json:"resultSetHandles"`
+	StartPosition    int               `json:"startPosition"`
+	NumRows          int               `json:"numRows"`
+	Data             [][]interface{}   `json:"data"`
+	Username         string            `json:"username"`
+	Password         string            `json:"password"`
+	Attributes       exasol.Attributes `json:"attributes"`
+}
+
+func (sess *session) serve() {
+	defer sess.rollback()
+	for {
+		_, message, err := sess.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if sess.compression {
+			r, err := zlib.NewReader(bytes.NewReader(message))
+			if err != nil {
+				return
+			}
+			message, err = io.ReadAll(r)
+			if err != nil {
+				return
+			}
+		}
+		req := &request{}
+		err = json.Unmarshal(message, req)
+		if err != nil {
+			sess.reply(nil, errorf("invalid request: %s", err))
+			continue
+		}
+		if req.Command == "abortQuery" {
+			continue
+		}
+		data, err := sess.handle(req)
+		err = sess.reply(data, err)
+		if err != nil || req.Command == "disconnect" {
+			return
+		}
+	}
+}
+
+func (sess *session) reply(data interface{}, err error) error {
+	resp := &response{
+		Status:       "ok",
+		ResponseData: data,
+	}
+	if err != nil {
+		resp.Status = "error"
+		resp.ResponseData = nil
+		sqlErr, ok := err.(*sqlError)
+		if !ok {
+			sqlErr = &sqlError{code: "00000", text: err.Error()}
+		}
+		resp.Exception = &exasol.Exception{
+			Text:    sqlErr.text,
+			SQLCode: sqlErr.code,
+		}
+	}
+	message, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	if !sess.compression {
+		return sess.ws.WriteMessage(websocket.TextMessage, message)
+	}
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	_, err = w.Write(message)
+	if err != nil {
+		return err
+	}
+	w.Close()
+	return sess.ws.WriteMessage(websocket.BinaryMessage, b.Bytes())
+}
+
+func (sess *session) handle(req *request) (interface{}, error) {
+	if !sess.authenticated {
+		if req.Command == "login" {
+			sess.loggingIn = true
+			return sess.publicKey(), nil
+		}
+		if sess.loggingIn && req.Command == "" {
+			return sess.authenticate(req)
+		}
+		return nil, errorf("not logged in")
+	}
+
+	switch req.Command {
+	case "execute":
+		return sess.execute(req.SQLText, nil)
+	case "createPreparedStatement":
+		n, err := countParams(req.SQLText)
+		if err != nil {
+			return nil, errorf("syntax error: %s", err)
+		}
+		sess.nextHandle++
+		sess.statements[sess.nextHandle] = req.SQLText
+		params := exasol.ParameterData{NumColumns: n}
+		for i := 0; i < n; i++ {
+			params.Columns = append(params.Columns, exasol.SQLQueryColumn{
+				Name:     "",
+				DataType: columnType(varcharType),
+			})
+		}
+		return exasol.CreatePreparedStatementResponse{
+			StatementHandle: sess.nextHandle,
+			ParameterData:   params,
+		}, nil
+	case "executePreparedStatement":
+		stmt, ok := sess.statements[req.StatementHandle]
+		if !ok {
+			return nil, errorf("prepared statement %d not found", req.StatementHandle)
+		}
+		return sess.executePrepared(stmt, req.Data, req.NumRows)
+	case "closePreparedStatement":
+		delete(sess.statements, req.StatementHandle)
+		return nil, nil
+	case "fetch":
+		return sess.fetch(req.ResultSetHandle, req.StartPosition)
+	case "closeResultSet":
+		for _, h := range req.ResultSetHandles {
+			delete(sess.resultSets, h)
+		}
+		return nil, nil
+	case "getAttributes", "setAttributes":
+		if req.Attributes.Autocommit != nil {
+			sess.autocommit = *req.Attributes.Autocommit
+		}
+		if req.Attributes.CurrentSchema != "" {
+			sess.schema = req.Attributes.CurrentSchema
+		}
+		return nil, nil
+	case "disconnect":
+		return nil, nil
+	}
+	return nil, errorf("command %s is not supported by the fake database", req.Command)
+}
+
+func (sess *session) publicKey() *exasol.PublicKeyResponse {
+	pub := &sess.server.key.PublicKey
+	der := x509.MarshalPKCS1PublicKey(pub)
+	return &exasol.PublicKeyResponse{
+		PublicKeyPem:      string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: der})),
+		PublicKeyModulus:  fmt.Sprintf("%X", pub.N),
+		PublicKeyExponent: fmt.Sprintf("%x", pub.E),
+	}
+}
+
+func (sess *session) authenticate(req *request) (interface{}, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(req.Password)
+	if err != nil {
+		return nil, errorf("invalid password encoding")
+	}
+	password, err := rsa.DecryptPKCS1v15(rand.Reader, sess.server.key, encrypted)
+	if err != nil {
+		return nil, errorf("invalid password encryption")
+	}
+
+	user := req.Username
+	if p, err := newParser(user, nil); err == nil {
+		if n, err := p.name(); err == nil && p.done() == nil {
+			user = n
+		}
+	}
+
+	s := sess.server
+	s.mu.Lock()
+	u, ok := s.catalog.users[user]
+	s.mu.Unlock()
+	if !ok || u.password == "" || u.password != string(password) {
+		return nil, &sqlError{code: "08004", text: "Connection exception - authentication failed."}
+	}
+
+	sess.authenticated = true
+	sess.loggingIn = false
+	sess.user = user
+	sess.schema = req.Attributes.CurrentSchema
+	if req.Attributes.Autocommit != nil {
+		sess.autocommit = *req.Attributes.Autocommit
+	}
+	defer func() {
+		sess.compression = req.Attributes.CompressionEnabled != nil && *req.Attributes.CompressionEnabled
+	}()
+	return &exasol.AuthResponse{
+		SessionID:             sess.id,
+		ProtocolVersion:       3,
+		ReleaseVersion:        ProductVersion,
+		DatabaseName:          DatabaseName,
+		ProductName:           "EXASolution",
+		MaxDataMessageSize:    4 * 1024 * 1024,
+		MaxIdentifierLength:   128,
+		MaxVarcharLength:      2000000,
+		IdentifierQuoteString: "\"",
+		TimeZone:              "UTC",
+		TimeZoneBehavior:      "INVALID SHIFT AMBIGUOUS ST",
+	}, nil
+}
+
+func (sess *session) execContext() *execContext {
+	return &execContext{
+		user:          sess.user,
+		schema:        sess.schema,
+		sessionID:     sess.id,
+		sessionParams: sess.params,
+	}
+}
+
+// execute runs a single statement and returns its result
+func (sess *session) execute(sql string, args []interface{}) (interface{}, error) {
+	rel, n, err := sess.run(sql, args)
+	if err != nil {
+		return nil, err
+	}
+	return sess.results(rel, n), nil
+}
+
+// executePrepared runs stmt once per row of the column major data
+func (sess *session) executePrepared(stmt string, data [][]interface{}, numRows int) (interface{}, error) {
+	if len(data) == 0 || numRows == 0 {
+		return sess.execute(stmt, nil)
+	}
+	var rel *relation
+	total := 0
+	for r := 0; r < numRows; r++ {
+		args := make([]interface{}, len(data))
+		for c := range data {
+			if r < len(data[c]) {
+				args[c] = data[c][r]
+			}
+		}
+		var n int
+		var err error
+		rel, n, err = sess.run(stmt, args)
+		if err != nil {
+			return nil, err
+		}
+		total += n
+	}
+	return sess.results(rel, total), nil
+}
+
+func (sess *session) results(rel *relation, n int) *results {
+	if rel == nil {
+		return &results{
+			NumResults: 1,
+			Results:    []interface{}{rowCountResult{ResultType: "rowCount", RowCount: n}},
+		}
+	}
+	data := exasol.SQLQueryResponseResultSetData{
+		NumColumns: len(rel.columns),
+		NumRows:    len(rel.rows),
+	}
+	for _, col := range rel.columns {
+		data.Columns = append(data.Columns, exasol.SQLQueryColumn{
+			Name:     col.name,
+			DataType: columnType(col.typ),
+		})
+	}
+	if len(rel.rows) > maxInlineRows {
+		sess.nextHandle++
+		sess.resultSets[sess.nextHandle] = rel
+		data.ResultSetHandle = sess.nextHandle
+	} else {
+		data.NumRowsInMessage = len(rel.rows)
+		data.Data = columnMajor(rel.columns, rel.rows)
+	}
+	return &results{
+		NumResults: 1,
+		Results:    []interface{}{resultSetResult{ResultType: "resultSet", ResultSet: data}},
+	}
+}
+
+func (sess *session) fetch(handle, start int) (interface{}, error) {
+	rel, ok := sess.resultSets[handle]
+	if !ok {
+		return nil, errorf("result set %d not found", handle)
+	}
+	if start < 0 || start > len(rel.rows) {
+		return nil, errorf("invalid start position %d", start)
+	}
+	end := start + fetchRows
+	if end > len(rel.rows) {
+		end = len(rel.rows)
+	}
+	rows := rel.rows[start:end]
+	return &exasol.SQLQueryResponseResultSetData{
+		NumRows: len(rows),
+		Data:    columnMajor(rel.columns, rows),
+	}, nil
+}
+
+// columnMajor transposes rows into the wire format
+func columnMajor(columns []relColumn, rows [][]interface{}) [][]interface{} {
+	data := make([][]interface{}, len(columns))
+	for c := range columns {
+		data[c] = make([]interface{}, len(rows))
+		for r, row := range rows {
+			data[c][r] = row[c]
+		}
+	}
+	return data
+}
+
+// run parses and executes sql. Queries return a relation, everything
+// else the number of affected rows.
+func (sess *session) run(sql string, args []interface{}) (*relation, int, error) {
+	for i, a := range args {
+		args[i] = normalizeValue(a)
+	}
+	st, err := parseStatement(sql, args)
+	if err != nil {
+		return nil, 0, err
+	}
+	s := sess.server
+
+	switch st.kind {
+	case kindQuery:
+		if sess.tx != nil {
+			rel, err := st.query.run(sess.execContext(), sess.tx)
+			return rel, 0, err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		rel, err := st.query.run(sess.execContext(), s.catalog)
+		return rel, 0, err
+	case kindCommit:
+		return nil, 0, sess.commit()
+	case kindRollback:
+		sess.rollback()
+		return nil, 0, nil
+	case kindSession:
+		c := sess.tx
+		if c == nil {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			c = s.catalog
+		}
+		return nil, 0, st.session(sess, c)
+	}
+
+	if sess.tx == nil {
+		s.mu.Lock()
+		sess.tx = s.catalog.clone()
+		sess.txVersion = s.version
+		s.mu.Unlock()
+	}
+	// Statements are atomic so work on a copy of the transaction
+	x := sess.execContext()
+	work := sess.tx.clone()
+	n, err := st.mutate(x, work)
+	if err != nil {
+		if sess.autocommit {
+			sess.rollback()
+		}
+		return nil, 0, err
+	}
+	sess.tx = work
+	sess.journal = append(sess.journal, journalEntry{x: *x, mutate: st.mutate})
+	if sess.autocommit {
+		err = sess.commit()
+	}
+	return nil, n, err
+}
+
+// commit publishes the transaction. If other sessions committed in
+// between, the journal is replayed on top of their changes. Failing
+// replays are reported as transaction collision.
+func (sess *session) commit() error {
+	defer sess.rollback()
+	if sess.tx == nil || len(sess.journal) == 0 {
+		return nil
+	}
+	s := sess.server
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.version == sess.txVersion {
+		s.catalog = sess.tx
+		s.version++
+		return nil
+	}
+	c := s.catalog.clone()
+	for _, entry := range sess.journal {
+		x := entry.x
+		_, err := entry.mutate(&x, c)
+		if err != nil {
+			return errCollision
+		}
+	}
+	s.catalog = c
+	s.version++
+	return nil
+}
+
+func (sess *session) rollback() {
+	sess.tx = nil
+	sess.journal = nil
+}
+
+// columnType renders the data type of a result set column
+func columnType(typ string) exasol.SQLQueryColumnType {
+	t := exasol.SQLQueryColumnType{Type: wireType(typ)}
+	var a, b int64
+	switch t.Type {
+	case "DECIMAL":
+		if n, _ := fmt.Sscanf(typ, "DECIMAL(%d,%d)", &a, &b); n == 2 {
+			t.Precision, t.Scale = &a, &b
+		}
+	case "VARCHAR", "CHAR":
+		var cs string
+		if n, _ := fmt.Sscanf(typ[len(t.Type):], "(%d) %s", &a, &cs); n == 2 {
+			t.Size, t.CharacterSet = &a, &cs
+		}
+	case "TIMESTAMP":
+		local := typ == "TIMESTAMP WITH LOCAL TIME ZONE"
+		t.WithLocalTimeZone = &local
+	}
+	return t
+}
@@ -0,0 +1,257 @@
+package fakeexasol_test
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/fakeexasol"
+	"github.com/exasol/exasol-driver-go"
+)
+
+var server *fakeexasol.Server
+
+func TestMain(m *testing.M) {
+	server = fakeexasol.NewServer()
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
+func open(t *testing.T, autocommit bool) *sql.DB {
+	validate := false
+	conf := &exasol.DSNConfig{
+		User:                      "sys",
+		Password:                  fakeexasol.DefaultPassword,
+		Host:                      server.Host(),
+		Port:                      server.Port(),
+		Autocommit:                &autocommit,
+		ValidateServerCertificate: &validate,
+	}
+	db, err := sql.Open("exasol", conf.ToDSN())
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+func TestLogin(t *testing.T) {
+	validate := false
+	conf := &exasol.DSNConfig{
+		User:                      "sys",
+		Password:                  "wrong",
+		Host:                      server.Host(),
+		Port:                      server.Port(),
+		ValidateServerCertificate: &validate,
+	}
+	db, err := sql.Open("exasol", conf.ToDSN())
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer db.Close()
+	err = db.Ping()
+	if err == nil || !strings.Contains(err.Error(), "authentication failed") {
+		t.Fatal("Expected authentication error:", err)
+	}
+}
+
+func TestQueryWithParameters(t *testing.T) {
+	db := open(t, true)
+	var name string
+	var n float64
+	err := db.QueryRow("SELECT UPPER(?) AS NAME, 1 + ? FROM DUAL", "foo", 2).Scan(&name, &n)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if name != "FOO" || n != 3 {
+		t.Fatalf("Unexpected row: %s %v", name, n)
+	}
+}
+
+func TestTransactionIsolation(t *testing.T) {
+	writer := open(t, false)
+	reader := open(t, true)
+
+	tx, err := writer.Begin()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	_, err = tx.Exec("CREATE SCHEMA ISOLATION_TEST")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if countSchemas(t, reader, "ISOLATION_TEST") != 0 {
+		t.Fatal("Uncommitted Schema visible to other session")
+	}
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	tx, err = writer.Begin()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	_, err = tx.Exec("CREATE SCHEMA ISOLATION_TEST")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if countSchemas(t, reader, "ISOLATION_TEST") != 1 {
+		t.Fatal("Committed Schema not visible to other session")
+	}
+	_, err = reader.Exec("DROP SCHEMA ISOLATION_TEST CASCADE")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+}
+
+func TestTransactionCollision(t *testing.T) {
+	first := open(t, false)
+	second := open(t, false)
+
+	tx1, err := first.Begin()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	tx2, err := second.Begin()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	_, err = tx1.Exec("CREATE ROLE COLLISION_TEST")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	_, err = tx2.Exec("CREATE ROLE COLLISION_TEST")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	err = tx1.Commit()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	err = tx2.Commit()
+	if err == nil || !strings.Contains(err.Error(), "GlobalTransactionRollback") {
+		t.Fatal("Expected transaction collision:", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	db := open(t, true)
+	stmts := []string{
+		"CREATE SCHEMA FETCH_TEST",
+		"CREATE TABLE FETCH_TEST.T (ID DECIMAL(18,0))",
+	}
+	for _, stmt := range stmts {
+		_, err := db.Exec(stmt)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+	}
+	defer db.Exec("DROP SCHEMA FETCH_TEST CASCADE")
+
+	values := []string{}
+	for i := 0; i < 2500; i++ {
+		values = append(values, fmt.Sprintf("(%d)", i))
+	}
+	_, err := db.Exec("INSERT INTO FETCH_TEST.T VALUES " + strings.Join(values, ", "))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	rows, err := db.Query("SELECT ID FROM FETCH_TEST.T ORDER BY ID")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer rows.Close()
+	expected := 0
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if id != expected {
+			t.Fatalf("Expected %d but got %d", expected, id)
+		}
+		expected++
+	}
+	if rows.Err() != nil {
+		t.Fatal("Unexpected error:", rows.Err())
+	}
+	if expected != 2500 {
+		t.Fatalf("Expected 2500 rows but got %d", expected)
+	}
+}
+
+func TestSystemViews(t *testing.T) {
+	db := open(t, true)
+	stmts := []string{
+		"CREATE SCHEMA SYSVIEW_TEST",
+		"CREATE TABLE SYSVIEW_TEST.T (ID INT PRIMARY KEY, NAME VARCHAR(20) COMMENT IS 'name') COMMENT IS 'table'",
+		"CREATE VIEW SYSVIEW_TEST.V AS SELECT NAME FROM SYSVIEW_TEST.T",
+	}
+	for _, stmt := range stmts {
+		_, err := db.Exec(stmt)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+	}
+	defer db.Exec("DROP SCHEMA SYSVIEW_TEST CASCADE")
+
+	var comment string
+	err := db.QueryRow("SELECT TABLE_COMMENT FROM SYS.EXA_ALL_TABLES WHERE TABLE_SCHEMA = 'SYSVIEW_TEST' AND TABLE_NAME = 'T'").Scan(&comment)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if comment != "table" {
+		t.Fatalf("Unexpected comment %s", comment)
+	}
+
+	rows, err := db.Query("SELECT COLUMN_NAME, COLUMN_TYPE, COLUMN_IS_NULLABLE FROM SYS.EXA_ALL_COLUMNS WHERE UPPER(COLUMN_SCHEMA) = UPPER(?) AND COLUMN_TABLE = 'T' ORDER BY COLUMN_ORDINAL_POSITION", "sysview_test")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer rows.Close()
+	got := []string{}
+	for rows.Next() {
+		var name, typ string
+		var nullable bool
+		err = rows.Scan(&name, &typ, &nullable)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		got = append(got, fmt.Sprintf("%s %s %t", name, typ, nullable))
+	}
+	expected := "ID DECIMAL(18,0) false, NAME VARCHAR(20) UTF8 true"
+	if strings.Join(got, ", ") != expected {
+		t.Fatalf("Expected %s but got %s", expected, strings.Join(got, ", "))
+	}
+
+	var object, referenced string
+	err = db.QueryRow("SELECT OBJECT_NAME, REFERENCED_OBJECT_NAME FROM SYS.EXA_DBA_DEPENDENCIES WHERE OBJECT_SCHEMA = 'SYSVIEW_TEST'").Scan(&object, &referenced)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if object != "V" || referenced != "T" {
+		t.Fatalf("Unexpected dependency %s -> %s", object, referenced)
+	}
+}
+
+func countSchemas(t *testing.T, db *sql.DB, name string) int {
+	var n int
+	err := db.QueryRow("SELECT COUNT(*) FROM SYS.EXA_SCHEMAS WHERE SCHEMA_NAME = ?", name).Scan(&n)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	return n
+}
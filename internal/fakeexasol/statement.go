@@ -0,0 +1,229 @@
+package fakeexasol
+
+import (
+	"strings"
+	"time"
+)
+
+// execContext is the state of the session a statement runs in
+type execContext struct {
+	user          string
+	schema        string
+	sessionID     int
+	sessionParams map[string]string
+	// depth of nested View evaluation
+	depth int
+}
+
+func (x *execContext) qualify(n objectName) (objectName, error) {
+	if n.schema != "" {
+		return n, nil
+	}
+	if x.schema == "" {
+		return n, errorf("no schema specified or opened or current schema has been dropped [line 1, column 1]")
+	}
+	return objectName{schema: x.schema, name: n.name}, nil
+}
+
+func (x *execContext) now() string {
+	return time.Now().UTC().Format(timestampFormat)
+}
+
+type statementKind int
+
+const (
+	kindQuery statementKind = iota
+	kindMutation
+	kindCommit
+	kindRollback
+	kindSession
+)
+
+// mutation changes the catalog and returns the number of affected rows
+type mutation func(x *execContext, c *catalog) (int, error)
+
+type statement struct {
+	kind    statementKind
+	query   *selectStmt
+	mutate  mutation
+	session func(s *session, c *catalog) error
+}
+
+// parseStatement parses a single statement with bind parameters
+func parseStatement(sql string, args []interface{}) (*statement, error) {
+	p, err := newParser(sql, args)
+	if err != nil {
+		return nil, err
+	}
+	st, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+	return st, p.done()
+}
+
+func (p *parser) statement() (*statement, error) {
+	t := p.peek()
+	switch {
+	case t.is("SELECT"), t.is("WITH"), t.is("("):
+		into, err := p.selectInto()
+		if err != nil {
+			return nil, err
+		}
+		q, err := p.query()
+		if err != nil {
+			return nil, err
+		}
+		if into != nil {
+			def := &tableDefinition{subquery: q}
+			return &statement{kind: kindMutation, mutate: def.create(*into, false, false)}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &statement{kind: kindQuery, query: q}, nil
+	case t.is("COMMIT"):
+		p.next()
+		p.accept("WORK")
+		return &statement{kind: kindCommit}, nil
+	case t.is("ROLLBACK"):
+		p.next()
+		p.accept("WORK")
+		return &statement{kind: kindRollback}, nil
+	case t.is("OPEN"):
+		p.next()
+		err := p.expect("SCHEMA")
+		if err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		return &statement{kind: kindSession, session: func(s *session, c *catalog) error {
+			_, err := c.schema(name)
+			if err != nil {
+				return err
+			}
+			s.schema = name
+			return nil
+		}}, nil
+	case t.is("CLOSE"):
+		p.next()
+		err := p.expect("SCHEMA")
+		return &statement{kind: kindSession, session: func(s *session, c *catalog) error {
+			s.schema = ""
+			return nil
+		}}, err
+	case t.is("ALTER") && p.peekN(1).is("SESSION"):
+		p.next()
+		p.next()
+		key, value, err := p.parameterAssignment()
+		if err != nil {
+			return nil, err
+		}
+		return &statement{kind: kindSession, session: func(s *session, c *catalog) error {
+			s.params[key] = value
+			return nil
+		}}, nil
+	}
+
+	m, err := p.mutation()
+	if err != nil {
+		return nil, err
+	}
+	return &statement{kind: kindMutation, mutate: m}, nil
+}
+
+// selectInto removes INTO TABLE <name> of SELECT ... INTO TABLE <name> FROM ...
+// and returns the name of the Table to create
+func (p *parser) selectInto() (*objectName, error) {
+	depth := 0
+	for i := p.i; i < len(p.tokens); i++ {
+		t := p.tokens[i]
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case depth == 0 && t.is("FROM"):
+			return nil, nil
+		case depth == 0 && t.is("INTO") && i+1 < len(p.tokens) && p.tokens[i+1].is("TABLE"):
+			start := p.i
+			p.i = i + 2
+			name, err := p.objectName()
+			if err != nil {
+				return nil, err
+			}
+			p.tokens = append(append([]token{}, p.tokens[:i]...), p.tokens[p.i:]...)
+			p.i = start
+			return &name, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *parser) mutation() (mutation, error) {
+	t := p.peek()
+	switch {
+	case t.is("CREATE"):
+		p.next()
+		return p.create()
+	case t.is("DROP"):
+		p.next()
+		return p.drop()
+	case t.is("ALTER"):
+		p.next()
+		return p.alter()
+	case t.is("RENAME"):
+		p.next()
+		return p.rename()
+	case t.is("COMMENT"):
+		p.next()
+		return p.comment()
+	case t.is("GRANT"):
+		p.next()
+		return p.grant()
+	case t.is("REVOKE"):
+		p.next()
+		return p.revoke()
+	case t.is("INSERT"):
+		p.next()
+		return p.insert()
+	case t.is("DELETE"):
+		p.next()
+		return p.deleteRows()
+	case t.is("UPDATE"):
+		p.next()
+		return p.update()
+	case t.is("TRUNCATE"):
+		p.next()
+		return p.truncate()
+	}
+	return nil, errorf("statement %s is not supported by the fake database", strings.ToUpper(t.text))
+}
+
+// parameterAssignment reads <key> = <value> of ALTER SESSION and ALTER SYSTEM
+func (p *parser) parameterAssignment() (string, string, error) {
+	err := p.expect("SET")
+	if err != nil {
+		return "", "", err
+	}
+	key, err := p.name()
+	if err != nil {
+		return "", "", err
+	}
+	err = p.expect("=")
+	if err != nil {
+		return "", "", err
+	}
+	t := p.next()
+	switch t.kind {
+	case tokenString, tokenNumber, tokenIdent:
+		return key, t.text, nil
+	case tokenParam:
+		v, err := p.arg()
+		return key, formatValue(v), err
+	}
+	return "", "", p.unexpected("value")
+}
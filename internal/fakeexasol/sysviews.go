@@ -0,0 +1,390 @@
+package fakeexasol
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// ProductVersion is reported as databaseProductVersion of EXA_METADATA
+	ProductVersion = "7.1.0"
+	// DatabaseName is reported as databaseName of EXA_METADATA
+	DatabaseName = "FAKE"
+)
+
+// sysViews are the supported views of schema SYS
+var sysViews = map[string]func(x *execContext, c *catalog) *relation{
+	"EXA_SCHEMAS":                  schemasView,
+	"EXA_ALL_SCHEMAS":              schemasView,
+	"EXA_ALL_TABLES":               tablesView,
+	"EXA_DBA_TABLES":               tablesView,
+	"EXA_ALL_COLUMNS":              columnsView,
+	"EXA_DBA_COLUMNS":              columnsView,
+	"EXA_ALL_VIEWS":                viewsView,
+	"EXA_DBA_VIEWS":                viewsView,
+	"EXA_ALL_CONSTRAINTS":          constraintsView,
+	"EXA_DBA_CONSTRAINTS":          constraintsView,
+	"EXA_ALL_CONSTRAINT_COLUMNS":   constraintColumnsView,
+	"EXA_DBA_CONSTRAINT_COLUMNS":   constraintColumnsView,
+	"EXA_ALL_ROLES":                rolesView,
+	"EXA_DBA_ROLES":                rolesView,
+	"EXA_ALL_USERS":                usersView,
+	"EXA_DBA_USERS":                usersView,
+	"EXA_DBA_CONNECTIONS":          connectionsView,
+	"EXA_ALL_CONNECTIONS":          connectionsView,
+	"EXA_DBA_SYS_PRIVS":            sysPrivsView,
+	"EXA_DBA_ROLE_PRIVS":           rolePrivsView,
+	"EXA_DBA_OBJ_PRIVS":            objPrivsView,
+	"EXA_DBA_CONNECTION_PRIVS":     connPrivsView,
+	"EXA_DBA_RESTRICTED_OBJ_PRIVS": restrictedPrivsView,
+	"EXA_DBA_IMPERSONATION_PRIVS":  impersonationPrivsView,
+	"EXA_DBA_DEPENDENCIES":         dependenciesView,
+	"EXA_ALL_DEPENDENCIES":         dependenciesView,
+	"EXA_ALL_OBJECTS":              objectsView,
+	"EXA_DBA_OBJECTS":              objectsView,
+	"EXA_METADATA":                 metadataView,
+	"EXA_PARAMETERS":               parametersView,
+}
+
+const (
+	varcharType = "VARCHAR(2000000) UTF8"
+	decimalType = "DECIMAL(18,0)"
+	booleanType = "BOOLEAN"
+)
+
+// newSysRelation creates a relation of VARCHAR columns. Columns
+// with a type suffix like "NAME:BOOLEAN" get that type instead.
+func newSysRelation(columns ...string) *relation {
+	rel := &relation{}
+	for _, col := range columns {
+		typ := varcharType
+		if i := strings.Index(col, ":"); i != -1 {
+			col, typ = col[:i], col[i+1:]
+		}
+		rel.columns = append(rel.columns, relColumn{name: col, typ: typ, nullable: true})
+	}
+	return rel
+}
+
+func (rel *relation) add(values ...interface{}) {
+	rel.rows = append(rel.rows, values)
+}
+
+// nullable maps empty strings to NULL
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func schemasView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("SCHEMA_NAME", "SCHEMA_OWNER", "SCHEMA_OBJECT_ID:"+decimalType, "SCHEMA_IS_VIRTUAL:"+booleanType, "SCHEMA_COMMENT", "CREATED")
+	for _, k := range sortedKeys(c.schemas) {
+		s := c.schemas[k]
+		rel.add(s.name, s.owner, float64(s.id), false, nullable(s.comment), s.created)
+	}
+	return rel
+}
+
+func tablesView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("TABLE_SCHEMA", "TABLE_NAME", "TABLE_OWNER", "TABLE_OBJECT_ID:"+decimalType, "TABLE_IS_VIRTUAL:"+booleanType, "TABLE_HAS_DISTRIBUTION_KEY:"+booleanType, "TABLE_HAS_PARTITION_KEY:"+booleanType, "TABLE_ROW_COUNT:"+decimalType, "DELETE_PERCENTAGE:"+decimalType, "TABLE_COMMENT", "CREATED")
+	for _, sk := range sortedKeys(c.schemas) {
+		s := c.schemas[sk]
+		for _, tk := range sortedKeys(s.tables) {
+			t := s.tables[tk]
+			distribution, partition := false, false
+			for _, col := range t.columns {
+				distribution = distribution || col.distribution
+				partition = partition || col.partition
+			}
+			rel.add(s.name, t.name, s.owner, float64(t.id), false, distribution, partition, float64(len(t.rows)), float64(0), nullable(t.comment), t.created)
+		}
+	}
+	return rel
+}
+
+func columnsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("COLUMN_SCHEMA", "COLUMN_TABLE", "COLUMN_OBJECT_TYPE", "COLUMN_NAME", "COLUMN_TYPE", "COLUMN_TYPE_ID:"+decimalType, "COLUMN_MAXSIZE:"+decimalType, "COLUMN_NUM_PREC:"+decimalType, "COLUMN_NUM_SCALE:"+decimalType, "COLUMN_ORDINAL_POSITION:"+decimalType, "COLUMN_IS_VIRTUAL:"+booleanType, "COLUMN_IS_NULLABLE:"+booleanType, "COLUMN_IS_DISTRIBUTION_KEY:"+booleanType, "COLUMN_PARTITION_KEY_ORDINAL_POSITION:"+decimalType, "COLUMN_DEFAULT", "COLUMN_IDENTITY", "COLUMN_OWNER", "COLUMN_OBJECT_ID:"+decimalType, "STATUS", "COLUMN_COMMENT")
+	add := func(s *schemaObject, table, typ string, id int, columns []*column) {
+		partition := 0
+		for i, col := range columns {
+			var partitionPosition interface{}
+			if col.partition {
+				partition++
+				partitionPosition = float64(partition)
+			}
+			var identity interface{}
+			if col.identity {
+				identity = "1"
+			}
+			rel.add(s.name, table, typ, col.name, col.typ, nil, nil, nil, nil, float64(i+1), false, col.nullable, col.distribution, partitionPosition, nullable(col.def), identity, s.owner, float64(id), nil, nullable(col.comment))
+		}
+	}
+	for _, sk := range sortedKeys(c.schemas) {
+		s := c.schemas[sk]
+		for _, tk := range sortedKeys(s.tables) {
+			t := s.tables[tk]
+			add(s, t.name, "TABLE", t.id, t.columns)
+		}
+		for _, vk := range sortedKeys(s.views) {
+			v := s.views[vk]
+			add(s, v.name, "VIEW", v.id, v.columns)
+		}
+	}
+	return rel
+}
+
+func viewsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("VIEW_SCHEMA", "VIEW_NAME", "SCOPE_SCHEMA", "VIEW_OWNER", "VIEW_OBJECT_ID:"+decimalType, "VIEW_TEXT", "VIEW_COMMENT", "CREATED")
+	for _, sk := range sortedKeys(c.schemas) {
+		s := c.schemas[sk]
+		for _, vk := range sortedKeys(s.views) {
+			v := s.views[vk]
+			rel.add(s.name, v.name, v.scope, s.owner, float64(v.id), v.text, nullable(v.comment), v.created)
+		}
+	}
+	return rel
+}
+
+func constraintsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("CONSTRAINT_SCHEMA", "CONSTRAINT_TABLE", "CONSTRAINT_TYPE", "CONSTRAINT_NAME", "CONSTRAINT_ENABLED:"+booleanType, "CONSTRAINT_OWNER")
+	for _, sk := range sortedKeys(c.schemas) {
+		s := c.schemas[sk]
+		for _, tk := range sortedKeys(s.tables) {
+			for _, con := range s.tables[tk].constraints {
+				rel.add(s.name, tk, con.typ, con.name, con.enabled, s.owner)
+			}
+		}
+	}
+	return rel
+}
+
+func constraintColumnsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("CONSTRAINT_SCHEMA", "CONSTRAINT_TABLE", "CONSTRAINT_TYPE", "CONSTRAINT_NAME", "CONSTRAINT_OWNER", "ORDINAL_POSITION:"+decimalType, "COLUMN_NAME", "REFERENCED_SCHEMA", "REFERENCED_TABLE", "REFERENCED_COLUMN")
+	for _, sk := range sortedKeys(c.schemas) {
+		s := c.schemas[sk]
+		for _, tk := range sortedKeys(s.tables) {
+			for _, con := range s.tables[tk].constraints {
+				for i, col := range con.columns {
+					var refSchema, refTable, refColumn interface{}
+					if con.typ == "FOREIGN KEY" {
+						refSchema, refTable = con.refSchema, con.refTable
+						if i < len(con.refColumns) {
+							refColumn = con.refColumns[i]
+						}
+					}
+					rel.add(s.name, tk, con.typ, con.name, s.owner, float64(i+1), col, refSchema, refTable, refColumn)
+				}
+			}
+		}
+	}
+	return rel
+}
+
+func rolesView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("ROLE_NAME", "CREATED", "ROLE_PRIORITY", "ROLE_CONSUMER_GROUP", "ROLE_COMMENT")
+	for _, k := range sortedKeys(c.roles) {
+		r := c.roles[k]
+		rel.add(r.name, r.created, nil, nil, nullable(r.comment))
+	}
+	return rel
+}
+
+func usersView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("USER_NAME", "CREATED", "DISTINGUISHED_NAME", "KERBEROS_PRINCIPAL", "OPENID_SUBJECT", "PASSWORD", "PASSWORD_STATE", "PASSWORD_STATE_CHANGED", "PASSWORD_EXPIRY", "PASSWORD_EXPIRY_DAYS", "PASSWORD_GRACE_DAYS", "PASSWORD_EXPIRY_POLICY", "FAILED_LOGIN_ATTEMPTS:"+decimalType, "USER_PRIORITY", "USER_CONSUMER_GROUP", "USER_COMMENT")
+	for _, k := range sortedKeys(c.users) {
+		u := c.users[k]
+		var password interface{}
+		if u.password != "" {
+			password = "********"
+		}
+		rel.add(u.name, u.created, nullable(u.ldap), nullable(u.kerberos), nullable(u.openid), password, nullable(u.passwordState), nullable(u.passwordChanged), nil, nil, nil, nullable(u.passwordPolicy), float64(u.failedLoginAttempts), nil, nil, nullable(u.comment))
+	}
+	return rel
+}
+
+func connectionsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("CONNECTION_NAME", "CONNECTION_STRING", "USER_NAME", "CREATED", "CONNECTION_COMMENT")
+	for _, k := range sortedKeys(c.connections) {
+		conn := c.connections[k]
+		rel.add(conn.name, conn.connString, nullable(conn.user), conn.created, nullable(conn.comment))
+	}
+	return rel
+}
+
+func sysPrivsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("GRANTEE", "PRIVILEGE", "ADMIN_OPTION:"+booleanType)
+	for _, p := range c.sysPrivs {
+		rel.add(p.grantee, p.privilege, p.admin)
+	}
+	return rel
+}
+
+func rolePrivsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("GRANTEE", "GRANTED_ROLE", "ADMIN_OPTION:"+booleanType)
+	for _, p := range c.rolePrivs {
+		rel.add(p.grantee, p.role, p.admin)
+	}
+	return rel
+}
+
+func objPrivsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("OBJECT_SCHEMA", "OBJECT_NAME", "OBJECT_TYPE", "PRIVILEGE", "GRANTEE", "GRANTOR", "OWNER")
+	for _, p := range c.objPrivs {
+		var schema interface{}
+		owner := ""
+		if p.typ == "SCHEMA" {
+			if s, ok := c.schemas[p.name]; ok {
+				owner = s.owner
+			}
+		} else {
+			schema = p.schema
+			if s, ok := c.schemas[p.schema]; ok {
+				owner = s.owner
+			}
+		}
+		rel.add(schema, p.name, p.typ, p.privilege, p.grantee, p.grantor, nullable(owner))
+	}
+	return rel
+}
+
+func connPrivsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("GRANTEE", "GRANTED_CONNECTION", "ADMIN_OPTION:"+booleanType)
+	for _, p := range c.connPrivs {
+		rel.add(p.grantee, p.connection, p.admin)
+	}
+	return rel
+}
+
+func restrictedPrivsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("OBJECT_SCHEMA", "OBJECT_NAME", "OBJECT_TYPE", "FOR_OBJECT_SCHEMA", "FOR_OBJECT_NAME", "FOR_OBJECT_TYPE", "PRIVILEGE", "GRANTEE", "GRANTOR", "OWNER")
+	for _, p := range c.restrictedPrivs {
+		rel.add(nil, p.connection, "CONNECTION", p.forSchema, p.forName, "SCRIPT", "ACCESS", p.grantee, p.grantor, "SYS")
+	}
+	return rel
+}
+
+func impersonationPrivsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("GRANTEE", "IMPERSONATION_ON", "GRANTOR")
+	for _, p := range c.impersonationPrivs {
+		rel.add(p.grantee, p.on, p.grantor)
+	}
+	return rel
+}
+
+// dependenciesView derives the dependencies of Views from the
+// objects their subqueries select from
+func objectsView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("OBJECT_NAME", "OBJECT_TYPE", "CREATED", "OWNER", "OBJECT_ID:"+decimalType, "ROOT_NAME", "ROOT_TYPE", "OBJECT_IS_VIRTUAL:"+booleanType, "OBJECT_COMMENT")
+	for _, sk := range sortedKeys(c.schemas) {
+		s := c.schemas[sk]
+		rel.add(s.name, "SCHEMA", s.created, s.owner, float64(s.id), s.owner, "USER", false, nullable(s.comment))
+		for _, tk := range sortedKeys(s.tables) {
+			t := s.tables[tk]
+			rel.add(t.name, "TABLE", t.created, s.owner, float64(t.id), s.name, "SCHEMA", false, nullable(t.comment))
+		}
+		for _, vk := range sortedKeys(s.views) {
+			v := s.views[vk]
+			rel.add(v.name, "VIEW", v.created, s.owner, float64(v.id), s.name, "SCHEMA", false, nullable(v.comment))
+		}
+	}
+	return rel
+}
+
+func dependenciesView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("OBJECT_SCHEMA", "OBJECT_NAME", "OBJECT_TYPE", "OBJECT_OWNER", "OBJECT_ID:"+decimalType, "REFERENCE_TYPE", "REFERENCED_OBJECT_SCHEMA", "REFERENCED_OBJECT_NAME", "REFERENCED_OBJECT_TYPE", "REFERENCED_OBJECT_OWNER", "REFERENCED_OBJECT_ID:"+decimalType)
+	for _, sk := range sortedKeys(c.schemas) {
+		s := c.schemas[sk]
+		for _, vk := range sortedKeys(s.views) {
+			v := s.views[vk]
+			p, err := newParser(v.subquery, nil)
+			if err != nil {
+				continue
+			}
+			q, err := p.query()
+			if err != nil {
+				continue
+			}
+			seen := map[objectName]bool{}
+			for _, ref := range q.references() {
+				if ref.schema == "" {
+					ref.schema = v.scope
+				}
+				if seen[ref] {
+					continue
+				}
+				seen[ref] = true
+				refSchema, ok := c.schemas[ref.schema]
+				if !ok {
+					continue
+				}
+				if t, ok := refSchema.tables[ref.name]; ok {
+					rel.add(s.name, v.name, "VIEW", s.owner, float64(v.id), "VIEW", refSchema.name, t.name, "TABLE", refSchema.owner, float64(t.id))
+				} else if rv, ok := refSchema.views[ref.name]; ok {
+					rel.add(s.name, v.name, "VIEW", s.owner, float64(v.id), "VIEW", refSchema.name, rv.name, "VIEW", refSchema.owner, float64(rv.id))
+				}
+			}
+		}
+	}
+	return rel
+}
+
+// references lists the objects read by the FROM clauses of s
+func (s *selectStmt) references() []objectName {
+	refs := []objectName{}
+	var addRef func(ref tableRef)
+	addRef = func(ref tableRef) {
+		if ref.sub != nil {
+			refs = append(refs, ref.sub.references()...)
+			return
+		}
+		if ref.name.schema == "SYS" || (ref.name.schema == "" && (ref.name.name == "DUAL" || strings.HasPrefix(ref.name.name, "EXA_"))) {
+			return
+		}
+		refs = append(refs, ref.name)
+	}
+	for _, core := range s.cores {
+		for _, from := range core.from {
+			addRef(from.ref)
+			for _, j := range from.joins {
+				addRef(j.ref)
+			}
+		}
+	}
+	return refs
+}
+
+func metadataView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("PARAM_NAME", "PARAM_VALUE", "IS_STATIC:"+booleanType)
+	metadata := map[string]string{
+		"databaseName":           DatabaseName,
+		"databaseProductName":    "EXASolution",
+		"databaseProductVersion": ProductVersion,
+		"databaseMajorVersion":   strings.SplitN(ProductVersion, ".", 2)[0],
+		"identifierQuoteString":  "\"",
+		"maxColumnNameLength":    "128",
+		"maxTableNameLength":     "128",
+	}
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		rel.add(k, metadata[k], true)
+	}
+	return rel
+}
+
+func parametersView(x *execContext, c *catalog) *relation {
+	rel := newSysRelation("PARAMETER_NAME", "SESSION_VALUE", "SYSTEM_VALUE")
+	for _, k := range sortedKeys(c.parameters) {
+		session := c.parameters[k]
+		if v, ok := x.sessionParams[k]; ok {
+			session = v
+		}
+		rel.add(k, nullable(session), nullable(c.parameters[k]))
+	}
+	return rel
+}
@@ -0,0 +1,213 @@
+package fakeexasol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// dataType parses a column type and renders it the way
+// EXA_ALL_COLUMNS reports it
+func (p *parser) dataType() (string, error) {
+	word, err := p.name()
+	if err != nil {
+		return "", err
+	}
+	switch word {
+	case "INT", "INTEGER":
+		return "DECIMAL(18,0)", nil
+	case "BIGINT":
+		return "DECIMAL(36,0)", nil
+	case "SMALLINT":
+		return "DECIMAL(9,0)", nil
+	case "TINYINT":
+		return "DECIMAL(3,0)", nil
+	case "DECIMAL", "DEC", "NUMERIC", "NUMBER":
+		nums, err := p.typeArgs()
+		if err != nil {
+			return "", err
+		}
+		precision, scale := 18, 0
+		if len(nums) > 0 {
+			precision = nums[0]
+		}
+		if len(nums) > 1 {
+			scale = nums[1]
+		}
+		return fmt.Sprintf("DECIMAL(%d,%d)", precision, scale), nil
+	case "DOUBLE", "FLOAT", "REAL":
+		p.accept("PRECISION")
+		_, err := p.typeArgs()
+		return "DOUBLE", err
+	case "BOOLEAN", "BOOL":
+		return "BOOLEAN", nil
+	case "DATE":
+		return "DATE", nil
+	case "TIMESTAMP":
+		if p.accept("WITH", "LOCAL", "TIME", "ZONE") {
+			return "TIMESTAMP WITH LOCAL TIME ZONE", nil
+		}
+		return "TIMESTAMP", nil
+	case "GEOMETRY":
+		nums, err := p.typeArgs()
+		if err != nil {
+			return "", err
+		}
+		if len(nums) > 0 {
+			return fmt.Sprintf("GEOMETRY(%d)", nums[0]), nil
+		}
+		return "GEOMETRY", nil
+	case "HASHTYPE":
+		size := 16
+		if p.accept("(") {
+			t := p.next()
+			n, err := strconv.Atoi(t.text)
+			if err != nil {
+				return "", errorf("invalid size %s", t.text)
+			}
+			if p.accept("BIT") {
+				n /= 8
+			} else {
+				p.accept("BYTE")
+			}
+			size = n
+			err = p.expect(")")
+			if err != nil {
+				return "", err
+			}
+		}
+		return fmt.Sprintf("HASHTYPE(%d BYTE)", size), nil
+	case "INTERVAL":
+		if p.accept("YEAR") {
+			nums, err := p.typeArgs()
+			if err != nil {
+				return "", err
+			}
+			err = p.expect("TO", "MONTH")
+			precision := 2
+			if len(nums) > 0 {
+				precision = nums[0]
+			}
+			return fmt.Sprintf("INTERVAL YEAR(%d) TO MONTH", precision), err
+		}
+		err := p.expect("DAY")
+		if err != nil {
+			return "", err
+		}
+		nums, err := p.typeArgs()
+		if err != nil {
+			return "", err
+		}
+		err = p.expect("TO", "SECOND")
+		if err != nil {
+			return "", err
+		}
+		fraction, err := p.typeArgs()
+		precision, fp := 2, 3
+		if len(nums) > 0 {
+			precision = nums[0]
+		}
+		if len(fraction) > 0 {
+			fp = fraction[0]
+		}
+		return fmt.Sprintf("INTERVAL DAY(%d) TO SECOND(%d)", precision, fp), err
+	case "CLOB":
+		return "VARCHAR(2000000) " + p.charset(), nil
+	case "LONG":
+		err := p.expect("VARCHAR")
+		return "VARCHAR(2000000) " + p.charset(), err
+	case "CHAR", "CHARACTER", "NCHAR", "VARCHAR", "VARCHAR2", "NVARCHAR", "NVARCHAR2":
+		name := "CHAR"
+		if word != "CHAR" && word != "CHARACTER" && word != "NCHAR" {
+			name = "VARCHAR"
+		}
+		if p.accept("VARYING") {
+			name = "VARCHAR"
+		}
+		size := 1
+		if p.accept("(") {
+			t := p.next()
+			n, err := strconv.Atoi(t.text)
+			if err != nil {
+				return "", errorf("invalid size %s", t.text)
+			}
+			size = n
+			p.acceptAny("CHAR", "BYTE")
+			err = p.expect(")")
+			if err != nil {
+				return "", err
+			}
+		} else if name == "VARCHAR" {
+			return "", p.unexpected("(")
+		}
+		return fmt.Sprintf("%s(%d) %s", name, size, p.charset()), nil
+	}
+	return "", errorf("unknown data type %s", word)
+}
+
+func (p *parser) charset() string {
+	p.accept("CHARACTER", "SET")
+	if cs, ok := p.acceptAny("UTF8", "ASCII"); ok {
+		return cs
+	}
+	return "UTF8"
+}
+
+// typeArgs reads optional (n[, m])
+func (p *parser) typeArgs() ([]int, error) {
+	if !p.accept("(") {
+		return nil, nil
+	}
+	nums := []int{}
+	for {
+		t := p.next()
+		n, err := strconv.Atoi(t.text)
+		if err != nil {
+			return nil, errorf("invalid type argument %s", t.text)
+		}
+		nums = append(nums, n)
+		if p.accept(")") {
+			return nums, nil
+		}
+		err = p.expect(",")
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// typeOfValue guesses the column type of computed values
+func typeOfValue(v interface{}) string {
+	switch t := v.(type) {
+	case float64:
+		if t == float64(int64(t)) {
+			return "DECIMAL(18,0)"
+		}
+		return "DOUBLE"
+	case bool:
+		return "BOOLEAN"
+	case string:
+		n := len([]rune(t))
+		if n == 0 {
+			n = 1
+		}
+		return fmt.Sprintf("VARCHAR(%d) UTF8", n)
+	}
+	return "VARCHAR(2000000) UTF8"
+}
+
+// wireType is the data type reported in result sets
+func wireType(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "INTERVAL YEAR"):
+		return "INTERVAL YEAR TO MONTH"
+	case strings.HasPrefix(typ, "INTERVAL DAY"):
+		return "INTERVAL DAY TO SECOND"
+	}
+	for _, prefix := range []string{"DECIMAL", "DOUBLE", "BOOLEAN", "DATE", "TIMESTAMP", "CHAR", "GEOMETRY", "HASHTYPE"} {
+		if strings.HasPrefix(typ, prefix) {
+			return prefix
+		}
+	}
+	return "VARCHAR"
+}
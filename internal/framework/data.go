@@ -0,0 +1,249 @@
+package framework
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Data exposes framework values as internal.Data with the same Go
+// types SDKv2 uses (string, int, float64, bool, []interface{},
+// map[string]interface{}). Null and unknown values are absent.
+type Data struct {
+	id     string
+	Values map[string]interface{}
+	// Prior holds the values Data was created with. HasChange and
+	// GetChange compare Values to it.
+	Prior map[string]interface{}
+	// Zero holds the values Get returns for absent attributes
+	Zero map[string]interface{}
+}
+
+// NewData converts the object value of a config, plan or state
+func NewData(v tftypes.Value) (*Data, error) {
+	d := &Data{
+		Values: map[string]interface{}{},
+	}
+	if v.IsNull() || !v.IsKnown() {
+		return d, nil
+	}
+
+	attrs := map[string]tftypes.Value{}
+	err := v.As(&attrs)
+	if err != nil {
+		return nil, err
+	}
+	for name, a := range attrs {
+		value, ok, err := goValue(a)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		if !ok {
+			continue
+		}
+		if name == "id" {
+			d.id, _ = value.(string)
+		}
+		d.Values[name] = value
+	}
+	d.Prior = copyValues(d.Values)
+	return d, nil
+}
+
+func copyValues(values map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		c[k] = v
+	}
+	return c
+}
+
+func (d *Data) Get(name string) interface{} {
+	v, ok := d.GetOk(name)
+	if !ok {
+		return d.Zero[name]
+	}
+	return v
+}
+
+func (d *Data) GetOk(name string) (interface{}, bool) {
+	v, ok := d.Values[name]
+	return v, ok
+}
+
+func (d *Data) Set(name string, value interface{}) error {
+	d.Values[name] = value
+	return nil
+}
+
+func (d *Data) Id() string {
+	return d.id
+}
+
+func (d *Data) SetId(id string) {
+	d.id = id
+}
+
+func (d *Data) HasChange(name string) bool {
+	old, new := d.GetChange(name)
+	return !reflect.DeepEqual(old, new)
+}
+
+func (d *Data) GetChange(name string) (interface{}, interface{}) {
+	return d.Prior[name], d.Values[name]
+}
+
+// Value converts Data back into an object value of type typ.
+// Attributes that were not set are null.
+func (d *Data) Value(typ tftypes.Object) (tftypes.Value, error) {
+	attrs := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		v, ok := d.Values[name]
+		if name == "id" {
+			v, ok = d.id, d.id != ""
+		}
+		if !ok {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+			continue
+		}
+		tfv, err := terraformValue(attrType, v)
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		attrs[name] = tfv
+	}
+	return tftypes.NewValue(typ, attrs), nil
+}
+
+func goValue(v tftypes.Value) (interface{}, bool, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, false, nil
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		return s, true, v.As(&s)
+	case typ.Is(tftypes.Bool):
+		var b bool
+		return b, true, v.As(&b)
+	case typ.Is(tftypes.Number):
+		var f big.Float
+		err := v.As(&f)
+		if err != nil {
+			return nil, false, err
+		}
+		if f.IsInt() {
+			i, _ := f.Int64()
+			return int(i), true, nil
+		}
+		f64, _ := f.Float64()
+		return f64, true, nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}):
+		var elems []tftypes.Value
+		err := v.As(&elems)
+		if err != nil {
+			return nil, false, err
+		}
+		l := make([]interface{}, 0, len(elems))
+		for _, e := range elems {
+			ev, _, err := goValue(e)
+			if err != nil {
+				return nil, false, err
+			}
+			l = append(l, ev)
+		}
+		return l, true, nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		elems := map[string]tftypes.Value{}
+		err := v.As(&elems)
+		if err != nil {
+			return nil, false, err
+		}
+		m := map[string]interface{}{}
+		for k, e := range elems {
+			ev, _, err := goValue(e)
+			if err != nil {
+				return nil, false, err
+			}
+			m[k] = ev
+		}
+		return m, true, nil
+	}
+	return nil, false, fmt.Errorf("unsupported type %s", typ)
+}
+
+func terraformValue(typ tftypes.Type, v interface{}) (tftypes.Value, error) {
+	if v == nil {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	switch t := typ.(type) {
+	case tftypes.Object:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected object: %#v", v)
+		}
+		attrs := map[string]tftypes.Value{}
+		for name, attrType := range t.AttributeTypes {
+			av, err := terraformValue(attrType, m[name])
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("attribute %s: %w", name, err)
+			}
+			attrs[name] = av
+		}
+		return tftypes.NewValue(t, attrs), nil
+	case tftypes.List:
+		return terraformElements(t, t.ElementType, v)
+	case tftypes.Set:
+		return terraformElements(t, t.ElementType, v)
+	case tftypes.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected map: %#v", v)
+		}
+		elems := map[string]tftypes.Value{}
+		for k, e := range m {
+			ev, err := terraformValue(t.ElementType, e)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems[k] = ev
+		}
+		return tftypes.NewValue(t, elems), nil
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return tftypes.NewValue(typ, new(big.Float).SetInt64(rv.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return tftypes.NewValue(typ, new(big.Float).SetUint64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return tftypes.NewValue(typ, big.NewFloat(rv.Float())), nil
+	}
+
+	err := tftypes.ValidateValue(typ, v)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return tftypes.NewValue(typ, v), nil
+}
+
+func terraformElements(typ tftypes.Type, elemType tftypes.Type, v interface{}) (tftypes.Value, error) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return tftypes.Value{}, fmt.Errorf("expected list: %#v", v)
+	}
+	elems := make([]tftypes.Value, 0, len(l))
+	for _, e := range l {
+		ev, err := terraformValue(elemType, e)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		elems = append(elems, ev)
+	}
+	return tftypes.NewValue(typ, elems), nil
+}
@@ -0,0 +1,122 @@
+package framework
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDataRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	typ := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":     tftypes.String,
+			"name":   tftypes.String,
+			"port":   tftypes.Number,
+			"hosts":  tftypes.List{ElementType: tftypes.String},
+			"params": tftypes.Map{ElementType: tftypes.String},
+			"empty":  tftypes.String,
+		},
+	}
+	v := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, nil),
+		"name": tftypes.NewValue(tftypes.String, "foo"),
+		"port": tftypes.NewValue(tftypes.Number, 8563),
+		"hosts": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "exa1"),
+		}),
+		"params": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"TIME_ZONE": tftypes.NewValue(tftypes.String, "UTC"),
+		}),
+		"empty": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	d, err := NewData(v)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	expected := map[string]interface{}{
+		"name":   "foo",
+		"port":   8563,
+		"hosts":  []interface{}{"exa1"},
+		"params": map[string]interface{}{"TIME_ZONE": "UTC"},
+	}
+	if !reflect.DeepEqual(d.Values, expected) {
+		t.Fatalf("Unexpected values: %#v", d.Values)
+	}
+
+	d.SetId("FOO")
+	out, err := d.Value(typ)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	read, err := NewData(out)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if read.Id() != "FOO" {
+		t.Fatalf("Unexpected id: %s", read.Id())
+	}
+	if read.Get("port") != 8563 {
+		t.Fatalf("Unexpected port: %#v", read.Get("port"))
+	}
+}
+
+func TestDataObjects(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":  tftypes.String,
+			"depth": tftypes.Number,
+		},
+	}
+	typ := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":         tftypes.String,
+			"name_regex": tftypes.String,
+			"objects":    tftypes.List{ElementType: objType},
+		},
+	}
+
+	d, err := NewData(tftypes.NewValue(typ, nil))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	d.Zero = map[string]interface{}{
+		"name_regex": "",
+	}
+	if d.Get("name_regex") != "" {
+		t.Fatalf("Expected zero value: %#v", d.Get("name_regex"))
+	}
+
+	d.Set("objects", []interface{}{
+		map[string]interface{}{
+			"name":  "FOO",
+			"depth": int64(1),
+		},
+	})
+	d.SetId("objects")
+	out, err := d.Value(typ)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	expected := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "objects"),
+		"name_regex": tftypes.NewValue(tftypes.String, nil),
+		"objects": tftypes.NewValue(tftypes.List{ElementType: objType}, []tftypes.Value{
+			tftypes.NewValue(objType, map[string]tftypes.Value{
+				"name":  tftypes.NewValue(tftypes.String, "FOO"),
+				"depth": tftypes.NewValue(tftypes.Number, 1),
+			}),
+		}),
+	})
+	if !out.Equal(expected) {
+		t.Fatalf("Unexpected value: %s", out)
+	}
+}
@@ -0,0 +1,119 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/logging"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ReadFunc reads a Data Source into d using the Client of the Provider
+type ReadFunc func(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics
+
+// DataSourceType serves a Data Source implemented on internal.Data
+// with the SDKv2 Schema it was written for
+type DataSourceType struct {
+	// Subsystem names the Data Source in logs
+	Subsystem string
+	Schema    map[string]*schema.Schema
+	Read      ReadFunc
+}
+
+type dataSource struct {
+	typ    DataSourceType
+	client *exaprovider.Client
+}
+
+func (t DataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	s, err := DataSourceSchema(t.Schema)
+	if err != nil {
+		diags.AddError("Invalid Data Source schema", err.Error())
+	}
+	return s, diags
+}
+
+func (t DataSourceType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, fwdiag.Diagnostics) {
+	c, diags := Client(p)
+	return &dataSource{
+		typ:    t,
+		client: c,
+	}, diags
+}
+
+func (ds *dataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	ctx = logging.WithSubsystem(ctx, ds.typ.Subsystem)
+
+	d, err := NewData(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Reading config failed", err.Error())
+		return
+	}
+	d.Zero = map[string]interface{}{}
+	for name, sch := range ds.typ.Schema {
+		d.Zero[name] = zeroValue(sch)
+		if _, ok := d.Values[name]; !ok && sch.Default != nil {
+			d.Values[name] = sch.Default
+		}
+	}
+
+	resp.Diagnostics.Append(Diagnostics(ds.typ.Read(ctx, d, ds.client))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	raw, err := d.Value(req.Config.Schema.TerraformType(ctx).(tftypes.Object))
+	if err != nil {
+		resp.Diagnostics.AddError("Writing state failed", err.Error())
+		return
+	}
+	resp.State.Raw = raw
+}
+
+// validateFunc runs a ValidateFunc of SDKv2 as framework validator
+type validateFunc schema.SchemaValidateFunc
+
+func (f validateFunc) Description(ctx context.Context) string {
+	return "Validated by the SDKv2 ValidateFunc of the attribute"
+}
+
+func (f validateFunc) MarkdownDescription(ctx context.Context) string {
+	return f.Description(ctx)
+}
+
+func (f validateFunc) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	v, err := req.AttributeConfig.ToTerraformValue(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Reading attribute failed", err.Error())
+		return
+	}
+	value, ok, err := goValue(v)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Reading attribute failed", err.Error())
+		return
+	}
+	if !ok {
+		return
+	}
+
+	key := fmt.Sprint(req.AttributePath)
+	if steps := req.AttributePath.Steps(); len(steps) != 0 {
+		if name, ok := steps[len(steps)-1].(tftypes.AttributeName); ok {
+			key = string(name)
+		}
+	}
+	warnings, errs := f(value, key)
+	for _, w := range warnings {
+		resp.Diagnostics.AddAttributeWarning(req.AttributePath, "Invalid attribute", w)
+	}
+	for _, e := range errs {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid attribute", e.Error())
+	}
+}
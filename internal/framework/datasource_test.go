@@ -0,0 +1,136 @@
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func TestDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	typ := DataSourceType{
+		Subsystem: "data_test",
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"max_depth": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  10,
+			},
+			"objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Read: func(ctx context.Context, d internal.Data, c *exaprovider.Client) diag.Diagnostics {
+			if d.Get("name_regex").(string) != "" {
+				return diag.Errorf("Unexpected name_regex: %s", d.Get("name_regex"))
+			}
+			if d.Get("max_depth").(int) != 10 {
+				return diag.Errorf("Unexpected max_depth: %d", d.Get("max_depth"))
+			}
+			d.Set("objects", []interface{}{
+				map[string]interface{}{
+					"name": "FOO",
+				},
+			})
+			d.SetId("objects")
+			return nil
+		},
+	}
+
+	s, diags := typ.GetSchema(ctx)
+	if diags.HasError() {
+		t.Fatal("Unexpected diagnostics:", diags)
+	}
+	if !s.Attributes["max_depth"].Computed {
+		t.Error("Expected attribute with Default to be computed")
+	}
+
+	objectsType := s.Attributes["objects"].Type.(types.ListType).ElemType.TerraformType(ctx)
+	tfType := s.TerraformType(ctx).(tftypes.Object)
+	config := tftypes.NewValue(tfType, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, nil),
+		"name_regex": tftypes.NewValue(tftypes.String, nil),
+		"max_depth":  tftypes.NewValue(tftypes.Number, nil),
+		"objects":    tftypes.NewValue(tftypes.List{ElementType: objectsType}, nil),
+	})
+
+	ds := &dataSource{
+		typ: typ,
+	}
+	resp := &tfsdk.ReadDataSourceResponse{
+		State: tfsdk.State{
+			Schema: s,
+		},
+	}
+	ds.Read(ctx, tfsdk.ReadDataSourceRequest{
+		Config: tfsdk.Config{
+			Raw:    config,
+			Schema: s,
+		},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal("Unexpected diagnostics:", resp.Diagnostics)
+	}
+
+	d, err := NewData(resp.State.Raw)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Id() != "objects" {
+		t.Errorf("Unexpected id: %s", d.Id())
+	}
+	if d.Get("max_depth") != 10 {
+		t.Errorf("Expected Default in state: %#v", d.Get("max_depth"))
+	}
+	if _, ok := d.GetOk("name_regex"); ok {
+		t.Error("Expected unset name_regex to stay null")
+	}
+}
+
+func TestDataSourceValidate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	v := validateFunc(validation.StringIsValidRegExp)
+	resp := &tfsdk.ValidateAttributeResponse{}
+	v.Validate(ctx, tfsdk.ValidateAttributeRequest{
+		AttributePath:   tftypes.NewAttributePath().WithAttributeName("name_regex"),
+		AttributeConfig: types.String{Value: "("},
+	}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected error for invalid regular expression")
+	}
+
+	resp = &tfsdk.ValidateAttributeResponse{}
+	v.Validate(ctx, tfsdk.ValidateAttributeRequest{
+		AttributePath:   tftypes.NewAttributePath().WithAttributeName("name_regex"),
+		AttributeConfig: types.String{Null: true},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Error("Unexpected error for null:", resp.Diagnostics)
+	}
+}
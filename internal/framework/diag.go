@@ -0,0 +1,19 @@
+package framework
+
+import (
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Diagnostics converts SDKv2 diagnostics to framework diagnostics
+func Diagnostics(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var converted fwdiag.Diagnostics
+	for _, d := range diags {
+		if d.Severity == diag.Warning {
+			converted.AddWarning(d.Summary, d.Detail)
+		} else {
+			converted.AddError(d.Summary, d.Detail)
+		}
+	}
+	return converted
+}
@@ -0,0 +1,29 @@
+package framework
+
+import (
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// ClientProvider is implemented by the framework Provider to hand
+// its configured Client to Resources and Data Sources
+type ClientProvider interface {
+	tfsdk.Provider
+	Client() *exaprovider.Client
+}
+
+// Client returns the Client configured by Provider p
+func Client(p tfsdk.Provider) (*exaprovider.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cp, ok := p.(ClientProvider)
+	if !ok {
+		diags.AddError("Unexpected Provider", "Provider does not offer an Exasol Client")
+		return nil, diags
+	}
+	c := cp.Client()
+	if c == nil {
+		diags.AddError("Unconfigured Provider", "Provider has not been configured")
+	}
+	return c, diags
+}
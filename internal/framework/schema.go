@@ -0,0 +1,145 @@
+// Package framework bridges terraform-plugin-framework types to the
+// internal.Data based implementations while the provider is muxed
+// between SDKv2 and the framework.
+//
+// Data Sources are served by the framework. Resources are still served
+// by SDKv2, upgraded to protocol 6 by tf5to6server, until they are
+// ported as well.
+package framework
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Attributes converts SDKv2 attributes to framework attributes.
+// The resulting protocol schema is identical, which is required for
+// muxing both servers.
+func Attributes(s map[string]*schema.Schema) (map[string]tfsdk.Attribute, error) {
+	attrs := map[string]tfsdk.Attribute{}
+	for name, sch := range s {
+		a, err := attribute(sch)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		attrs[name] = a
+	}
+	return attrs, nil
+}
+
+// DataSourceSchema converts the SDKv2 Schema of a Data Source.
+// Attributes with a Default are Computed so that the Default can be
+// read back into the state and ValidateFunc is run as a validator.
+func DataSourceSchema(s map[string]*schema.Schema) (tfsdk.Schema, error) {
+	attrs := map[string]tfsdk.Attribute{
+		"id": {
+			Type:     types.StringType,
+			Computed: true,
+		},
+	}
+	for name, sch := range s {
+		if len(sch.ConflictsWith) != 0 || len(sch.ExactlyOneOf) != 0 || len(sch.AtLeastOneOf) != 0 || len(sch.RequiredWith) != 0 || sch.ValidateDiagFunc != nil {
+			return tfsdk.Schema{}, fmt.Errorf("attribute %s: unsupported validation", name)
+		}
+		a, err := attribute(sch)
+		if err != nil {
+			return tfsdk.Schema{}, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		if sch.Default != nil {
+			a.Computed = true
+		}
+		if sch.ValidateFunc != nil {
+			a.Validators = []tfsdk.AttributeValidator{validateFunc(sch.ValidateFunc)}
+		}
+		attrs[name] = a
+	}
+	return tfsdk.Schema{
+		Attributes: attrs,
+	}, nil
+}
+
+func attribute(sch *schema.Schema) (tfsdk.Attribute, error) {
+	typ, err := attributeType(sch)
+	if err != nil {
+		return tfsdk.Attribute{}, err
+	}
+	return tfsdk.Attribute{
+		Type:               typ,
+		Required:           sch.Required,
+		Optional:           sch.Optional,
+		Computed:           sch.Computed,
+		Sensitive:          sch.Sensitive,
+		Description:        sch.Description,
+		DeprecationMessage: sch.Deprecated,
+	}, nil
+}
+
+func attributeType(sch *schema.Schema) (attr.Type, error) {
+	switch sch.Type {
+	case schema.TypeString:
+		return types.StringType, nil
+	case schema.TypeInt:
+		return types.Int64Type, nil
+	case schema.TypeFloat:
+		return types.Float64Type, nil
+	case schema.TypeBool:
+		return types.BoolType, nil
+	case schema.TypeList, schema.TypeSet, schema.TypeMap:
+		elemType, err := elemType(sch)
+		if err != nil {
+			return nil, err
+		}
+		switch sch.Type {
+		case schema.TypeList:
+			return types.ListType{ElemType: elemType}, nil
+		case schema.TypeSet:
+			return types.SetType{ElemType: elemType}, nil
+		}
+		return types.MapType{ElemType: elemType}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", sch.Type)
+}
+
+func elemType(sch *schema.Schema) (attr.Type, error) {
+	switch elem := sch.Elem.(type) {
+	case *schema.Schema:
+		return attributeType(elem)
+	case *schema.Resource:
+		// Only computed blocks are attributes in the protocol schema
+		// of SDKv2
+		if sch.Type == schema.TypeMap || sch.Optional || sch.Required {
+			return nil, fmt.Errorf("unsupported block")
+		}
+		attrTypes := map[string]attr.Type{}
+		for name, s := range elem.Schema {
+			typ, err := attributeType(s)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s: %w", name, err)
+			}
+			attrTypes[name] = typ
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+	}
+	return nil, fmt.Errorf("unsupported element %T", sch.Elem)
+}
+
+// zeroValue is the value SDKv2 returns for an attribute that is not set
+func zeroValue(sch *schema.Schema) interface{} {
+	switch sch.Type {
+	case schema.TypeString:
+		return ""
+	case schema.TypeInt:
+		return 0
+	case schema.TypeFloat:
+		return 0.0
+	case schema.TypeBool:
+		return false
+	case schema.TypeMap:
+		return map[string]interface{}{}
+	}
+	return []interface{}{}
+}
@@ -0,0 +1,50 @@
+// Package logging routes diagnostics of the provider through tflog.
+// Every Resource logs into its own subsystem so that e.g.
+// TF_LOG_PROVIDER_EXASOL_TABLE=trace only traces Tables.
+package logging
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type subsystemKey struct{}
+
+// WithSubsystem returns a Context that logs into subsystem
+func WithSubsystem(ctx context.Context, subsystem string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, subsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_EXASOL", subsystem))
+	return context.WithValue(ctx, subsystemKey{}, subsystem)
+}
+
+func subsystem(ctx context.Context) (string, bool) {
+	s, ok := ctx.Value(subsystemKey{}).(string)
+	return s, ok
+}
+
+// Trace logs msg with additional fields at trace level
+func Trace(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	if s, ok := subsystem(ctx); ok {
+		tflog.SubsystemTrace(ctx, s, msg, fields...)
+		return
+	}
+	tflog.Trace(ctx, msg, fields...)
+}
+
+// Debug logs msg with additional fields at debug level
+func Debug(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	if s, ok := subsystem(ctx); ok {
+		tflog.SubsystemDebug(ctx, s, msg, fields...)
+		return
+	}
+	tflog.Debug(ctx, msg, fields...)
+}
+
+// Warn logs msg with additional fields at warn level
+func Warn(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	if s, ok := subsystem(ctx); ok {
+		tflog.SubsystemWarn(ctx, s, msg, fields...)
+		return
+	}
+	tflog.Warn(ctx, msg, fields...)
+}
@@ -0,0 +1,199 @@
+// Package profile loads connection settings from a profile file
+// similar to the shared config of AWS.
+//
+// A profile file is either INI
+//
+//	[default]
+//	host = 10.0.0.11..14
+//	port = 8563
+//	username = sys
+//	password = exasol
+//
+// or JSON
+//
+//	{"default": {"host": "10.0.0.11..14", "port": 8563}}
+//
+// Instead of username and password an OpenID access_token or
+// refresh_token may be given. The provider rejects them as long as
+// the bundled driver cannot log in with them.
+package profile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultName is the profile used when no name is given
+	DefaultName = "default"
+)
+
+// Profile holds the connection settings of one named profile.
+// Settings that are not part of the profile are left empty.
+type Profile struct {
+	Host                      string
+	Port                      int
+	Username                  string
+	Password                  string
+	AccessToken               string
+	RefreshToken              string
+	Encryption                *bool
+	ValidateServerCertificate *bool
+	CertificateFingerprint    string
+}
+
+// DefaultPath returns the path of the profile file in the home
+// directory of the current user
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".exasol", "profiles"), nil
+}
+
+// Load reads the profile with name from the file at path
+func Load(path, name string) (*Profile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = DefaultName
+	}
+
+	p, err := Parse(content, name)
+	if err != nil {
+		return nil, fmt.Errorf("reading profile %s from %s failed: %w", name, path, err)
+	}
+	return p, nil
+}
+
+// Parse reads the profile with name from INI or JSON content
+func Parse(content []byte, name string) (*Profile, error) {
+	var values map[string]string
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		values, err = parseJSON(content, name)
+	} else {
+		values, err = parseINI(content, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return nil, fmt.Errorf("profile %s not found", name)
+	}
+	return fromValues(values)
+}
+
+func parseJSON(content []byte, name string) (map[string]string, error) {
+	profiles := map[string]map[string]interface{}{}
+	err := json.Unmarshal(content, &profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := profiles[name]
+	if !ok {
+		return nil, nil
+	}
+
+	values := map[string]string{}
+	for k, v := range p {
+		switch t := v.(type) {
+		case string:
+			values[k] = t
+		case float64:
+			values[k] = strconv.FormatFloat(t, 'f', -1, 64)
+		case bool:
+			values[k] = strconv.FormatBool(t)
+		default:
+			return nil, fmt.Errorf("unsupported value for %s: %#v", k, v)
+		}
+	}
+	return values, nil
+}
+
+func parseINI(content []byte, name string) (map[string]string, error) {
+	var values map[string]string
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for i := 1; scanner.Scan(); i++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == name && values == nil {
+				values = map[string]string{}
+			}
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("line %d: expected key = value", i)
+		}
+		if section != name {
+			continue
+		}
+		values[strings.TrimSpace(parts[0])] = strings.Trim(strings.TrimSpace(parts[1]), `"`)
+	}
+
+	return values, scanner.Err()
+}
+
+func fromValues(values map[string]string) (*Profile, error) {
+	p := &Profile{}
+	for k, v := range values {
+		var err error
+		switch k {
+		case "host":
+			p.Host = v
+		case "port":
+			p.Port, err = strconv.Atoi(v)
+		case "username", "user":
+			p.Username = v
+		case "password":
+			p.Password = v
+		case "access_token":
+			p.AccessToken = v
+		case "refresh_token":
+			p.RefreshToken = v
+		case "encryption":
+			p.Encryption, err = parseBool(v)
+		case "validate_server_certificate":
+			p.ValidateServerCertificate, err = parseBool(v)
+		case "certificate_fingerprint":
+			p.CertificateFingerprint = v
+		default:
+			err = fmt.Errorf("unknown setting")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", k, err)
+		}
+	}
+	if p.AccessToken != "" && p.RefreshToken != "" {
+		return nil, fmt.Errorf("only one of access_token and refresh_token may be set")
+	}
+	return p, nil
+}
+
+func parseBool(v string) (*bool, error) {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
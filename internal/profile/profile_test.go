@@ -0,0 +1,129 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseINI(t *testing.T) {
+	t.Parallel()
+
+	content := `# Exasol profiles
+[default]
+host = localhost
+
+[prod]
+host = 10.0.0.11..14
+port = 8564
+username = "admin"
+password = secret
+validate_server_certificate = false
+certificate_fingerprint = ABCDEF
+`
+	p, err := Parse([]byte(content), "prod")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if p.Host != "10.0.0.11..14" {
+		t.Errorf("Unexpected host: %s", p.Host)
+	}
+	if p.Port != 8564 {
+		t.Errorf("Unexpected port: %d", p.Port)
+	}
+	if p.Username != "admin" {
+		t.Errorf("Unexpected username: %s", p.Username)
+	}
+	if p.Password != "secret" {
+		t.Errorf("Unexpected password: %s", p.Password)
+	}
+	if p.ValidateServerCertificate == nil || *p.ValidateServerCertificate {
+		t.Errorf("Unexpected validate_server_certificate: %#v", p.ValidateServerCertificate)
+	}
+	if p.Encryption != nil {
+		t.Errorf("Unexpected encryption: %#v", p.Encryption)
+	}
+	if p.CertificateFingerprint != "ABCDEF" {
+		t.Errorf("Unexpected certificate_fingerprint: %s", p.CertificateFingerprint)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	content := `{
+	"default": {
+		"host": "exa1,exa2",
+		"port": 8563,
+		"user": "sys",
+		"refresh_token": "eyJhbGciOi",
+		"encryption": true
+	}
+}`
+	p, err := Parse([]byte(content), DefaultName)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if p.Host != "exa1,exa2" {
+		t.Errorf("Unexpected host: %s", p.Host)
+	}
+	if p.Port != 8563 {
+		t.Errorf("Unexpected port: %d", p.Port)
+	}
+	if p.Username != "sys" {
+		t.Errorf("Unexpected username: %s", p.Username)
+	}
+	if p.Encryption == nil || !*p.Encryption {
+		t.Errorf("Unexpected encryption: %#v", p.Encryption)
+	}
+	if p.RefreshToken != "eyJhbGciOi" || p.AccessToken != "" {
+		t.Errorf("Unexpected tokens: %s %s", p.AccessToken, p.RefreshToken)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte("[default]\nhost = foo\n"), "prod")
+	if err == nil {
+		t.Error("Expected error for missing profile")
+	}
+
+	_, err = Parse([]byte("[default]\nhots = foo\n"), DefaultName)
+	if err == nil {
+		t.Error("Expected error for unknown setting")
+	}
+
+	_, err = Parse([]byte("[default]\nport = foo\n"), DefaultName)
+	if err == nil {
+		t.Error("Expected error for invalid port")
+	}
+
+	_, err = Parse([]byte(`{"default": {"port": [1]}}`), DefaultName)
+	if err == nil {
+		t.Error("Expected error for invalid JSON value")
+	}
+
+	_, err = Parse([]byte("[default]\naccess_token = a\nrefresh_token = r\n"), DefaultName)
+	if err == nil {
+		t.Error("Expected error for access_token and refresh_token")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "profiles")
+	err := os.WriteFile(path, []byte("[default]\nhost = foo\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(path, "")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if p.Host != "foo" {
+		t.Errorf("Unexpected host: %s", p.Host)
+	}
+}
@@ -0,0 +1,87 @@
+package resourceprovider
+
+import (
+	"context"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/datasources"
+	dconn "github.com/abergmeier/terraform-provider-exasol/internal/datasources/connection"
+	ddb "github.com/abergmeier/terraform-provider-exasol/internal/datasources/database"
+	ddeps "github.com/abergmeier/terraform-provider-exasol/internal/datasources/dependencies"
+	dquery "github.com/abergmeier/terraform-provider-exasol/internal/datasources/query"
+	drole "github.com/abergmeier/terraform-provider-exasol/internal/datasources/role"
+	droles "github.com/abergmeier/terraform-provider-exasol/internal/datasources/roles"
+	dschemas "github.com/abergmeier/terraform-provider-exasol/internal/datasources/schemas"
+	dtable "github.com/abergmeier/terraform-provider-exasol/internal/datasources/table"
+	dtables "github.com/abergmeier/terraform-provider-exasol/internal/datasources/tables"
+	dusers "github.com/abergmeier/terraform-provider-exasol/internal/datasources/users"
+	dview "github.com/abergmeier/terraform-provider-exasol/internal/datasources/view"
+	dviews "github.com/abergmeier/terraform-provider-exasol/internal/datasources/views"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/framework"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// frameworkProvider serves the Data Sources, which are ported to
+// terraform-plugin-framework. It is muxed with Provider, which serves
+// the Resources, until every Resource is ported.
+type frameworkProvider struct {
+	client *exaprovider.Client
+}
+
+func FrameworkProvider() tfsdk.Provider {
+	return &frameworkProvider{}
+}
+
+func (p *frameworkProvider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	// Muxing requires the exact same provider schema in both servers
+	attrs, err := framework.Attributes(Provider().Schema)
+	if err != nil {
+		diags.AddError("Invalid provider schema", err.Error())
+	}
+	return tfsdk.Schema{
+		Attributes: attrs,
+	}, diags
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
+	d, err := framework.NewData(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Reading provider config failed", err.Error())
+		return
+	}
+
+	c, err := providerConfigure(d)
+	if err != nil {
+		resp.Diagnostics.AddError("Configuring provider failed", err.Error())
+		return
+	}
+	p.client = c.(*exaprovider.Client)
+}
+
+func (p *frameworkProvider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
+	return map[string]tfsdk.ResourceType{}, nil
+}
+
+func (p *frameworkProvider) GetDataSources(ctx context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
+	return map[string]tfsdk.DataSourceType{
+		"exasol_connection":          dconn.DataSourceType(),
+		"exasol_database":            ddb.DataSourceType(),
+		"exasol_object_dependencies": ddeps.DataSourceType(),
+		"exasol_physical_schema":     datasources.PhysicalSchemaType{},
+		"exasol_query":               dquery.DataSourceType(),
+		"exasol_role":                drole.DataSourceType(),
+		"exasol_roles":               droles.DataSourceType(),
+		"exasol_schemas":             dschemas.DataSourceType(),
+		"exasol_table":               dtable.DataSourceType(),
+		"exasol_tables":              dtables.DataSourceType(),
+		"exasol_users":               dusers.DataSourceType(),
+		"exasol_view":                dview.DataSourceType(),
+		"exasol_views":               dviews.DataSourceType(),
+	}, nil
+}
+
+func (p *frameworkProvider) Client() *exaprovider.Client {
+	return p.client
+}
@@ -0,0 +1,42 @@
+package resourceprovider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withSubsystems lets every Resource log into a subsystem named
+// after the Resource (e.g. table for exasol_table). Data sources
+// get a data_ prefix.
+func withSubsystems(resources map[string]*schema.Resource, prefix string) {
+	for name, r := range resources {
+		withSubsystem(prefix+strings.TrimPrefix(name, "exasol_"), r)
+	}
+}
+
+func withSubsystem(subsystem string, r *schema.Resource) {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return f(logging.WithSubsystem(ctx, subsystem), d, meta)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		imp := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			return imp(logging.WithSubsystem(ctx, subsystem), d, meta)
+		}
+	}
+}
@@ -1,25 +1,20 @@
 package resourceprovider
 
 import (
-	"flag"
-	"os"
 	"testing"
 
-	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 var (
 	exaClient *exaprovider.Client
 )
 
+// TestMain also runs the sweepers when called with -sweep
 func TestMain(m *testing.M) {
-	flag.Parse()
-	os.Exit(testRun(m))
-}
-
-func testRun(m *testing.M) int {
-	exaClient = internal.MustCreateTestClient()
+	exaClient = testclient.MustCreateTestClient()
 
-	return m.Run()
+	resource.TestMain(m)
 }
@@ -0,0 +1,34 @@
+package resourceprovider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// MuxServer combines the SDKv2 Provider p and the framework Provider
+// into one protocol 6 server. p is upgraded from protocol 5.
+func MuxServer(ctx context.Context, p *schema.Provider) (func() tfprotov6.ProviderServer, error) {
+	upgraded, err := tf5to6server.UpgradeServer(ctx, func() tfprotov5.ProviderServer {
+		return p.GRPCProvider()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		func() tfprotov6.ProviderServer {
+			return upgraded
+		},
+		providerserver.NewProtocol6(FrameworkProvider()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
@@ -0,0 +1,75 @@
+package resourceprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMuxServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	serverFunc, err := MuxServer(ctx, Provider())
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	resp, err := serverFunc().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Error("Unexpected diagnostic:", d.Summary, d.Detail)
+	}
+
+	for _, name := range []string{"exasol_physical_schema", "exasol_query", "exasol_users"} {
+		if _, ok := resp.DataSourceSchemas[name]; !ok {
+			t.Error("Expected framework Data Source", name)
+		}
+	}
+	if _, ok := resp.ResourceSchemas["exasol_table"]; !ok {
+		t.Error("Expected SDK Resource exasol_table")
+	}
+}
+
+func TestMuxServerValidateProviderConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	serverFunc, err := MuxServer(ctx, Provider())
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	server := serverFunc()
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	typ := schemaResp.Provider.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["host"] = tftypes.NewValue(tftypes.String, "10.0.0.11..14")
+	values["port"] = tftypes.NewValue(tftypes.Number, 8564)
+
+	config, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	resp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{
+		Config: &config,
+	})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Error("Unexpected diagnostic:", d.Summary, d.Detail)
+	}
+}
@@ -1,49 +1,86 @@
 package resourceprovider
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/profile"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/exasol/exasol-driver-go"
 )
 
+const (
+	defaultPort = 8563
+)
+
 func providerConfigure(d internal.Data) (interface{}, error) {
 
 	var conf *exasol.DSNConfig
 	var err error
-	dsn := d.Get("dsn").(string)
+	dsn := stringValue(d, "dsn")
 	if dsn == "" {
 
-		h := d.Get("ip")
-		if h == nil {
-			h = d.Get("host")
+		// Explicit configuration wins over environment which
+		// wins over the profile
+		p, err := configuredProfile(d)
+		if err != nil {
+			return nil, err
 		}
-		var host string
-		if h == nil {
-			host = ""
-		} else {
-			host = h.(string)
+
+		hosts, err := configuredHosts(d, p)
+		if err != nil {
+			return nil, err
 		}
-		if host == "" {
-			host = os.Getenv("EXAHOST")
+		if hosts.Host == "" {
+			return nil, fmt.Errorf("no host configured: set host, hosts, EXAHOST or a profile")
+		}
+		for _, host := range strings.Split(hosts.Host, ",") {
+			// The DSN parser of exasol-driver-go v0.3.0 splits
+			// host and port at every colon
+			if exaprovider.IsIPv6(host) {
+				return nil, fmt.Errorf("IPv6 address %s is not supported by exasol-driver-go v0.3.0: use a host name resolving to it", host)
+			}
+		}
+
+		username := firstNonEmpty(stringValue(d, "username"), os.Getenv("EXAUID"), p.Username)
+		password := firstNonEmpty(stringValue(d, "password"), os.Getenv("EXAPWD"))
+		token := firstNonEmpty(stringValue(d, "access_token"), stringValue(d, "refresh_token"))
+		if password == "" && token == "" {
+			// Credentials of the profile are only used as a whole
+			password = p.Password
+			token = firstNonEmpty(p.AccessToken, p.RefreshToken)
+		}
+		if password != "" && token != "" {
+			return nil, fmt.Errorf("only one of password and token may be configured")
+		}
+		if token != "" {
+			// exasol-driver-go v0.3.0 has no OpenID login and
+			// would log in with an empty password instead
+			return nil, fmt.Errorf("OpenID tokens are not supported by exasol-driver-go v0.3.0: configure a password instead")
 		}
 
-		username := d.Get("username").(string)
-		if username == "" {
-			username = os.Getenv("EXAUID")
+		port, _ := d.Get("port").(int)
+		if port == 0 {
+			port = hosts.Port
 		}
-		password := d.Get("password").(string)
-		if password == "" {
-			password = os.Getenv("EXAPWD")
+		if port == 0 {
+			port = p.Port
+		}
+		if port == 0 {
+			port = defaultPort
 		}
-		port := int(d.Get("port").(int))
 
 		conf = &exasol.DSNConfig{
-			User:     username,
-			Password: password,
-			Port:     port,
-			Host:     host,
+			User:                      username,
+			Password:                  password,
+			Port:                      port,
+			Host:                      hosts.Host,
+			Encryption:                p.Encryption,
+			ValidateServerCertificate: p.ValidateServerCertificate,
+			CertificateFingerprint:    p.CertificateFingerprint,
 		}
 	} else {
 		conf, err = exasol.ParseDSN(dsn)
@@ -52,5 +89,103 @@ func providerConfigure(d internal.Data) (interface{}, error) {
 		}
 	}
 
-	return exaprovider.NewClient(conf), nil
+	fetchSize, _ := d.Get("fetch_size").(int)
+	if fetchSize != 0 {
+		conf.FetchSize = fetchSize
+	}
+	maxRows, _ := d.Get("resultset_max_rows").(int)
+	if maxRows != 0 {
+		conf.ResultSetMaxRows = maxRows
+	}
+
+	session, err := configuredSession(d)
+	if err != nil {
+		return nil, err
+	}
+
+	c := exaprovider.NewClient(conf)
+	c.Defaults = argument.Defaults{
+		Schema:       stringValue(d, "default_schema"),
+		ObjectPrefix: stringValue(d, "object_prefix"),
+	}
+	c.Session = session
+	return c, nil
+}
+
+func configuredSession(d internal.Data) (exaprovider.Session, error) {
+	s := exaprovider.Session{}
+	s.QueryTimeout, _ = d.Get("query_timeout").(int)
+
+	if m, ok := d.Get("session_parameters").(map[string]interface{}); ok && len(m) != 0 {
+		s.Parameters = map[string]string{}
+		for k, v := range m {
+			s.Parameters[k], _ = v.(string)
+		}
+	}
+
+	err := s.Validate()
+	if err != nil {
+		return exaprovider.Session{}, fmt.Errorf("invalid session settings: %w", err)
+	}
+	return s, nil
+}
+
+// configuredProfile loads the profile from config_file or EXA_CONFIG_FILE.
+// Returns an empty Profile when neither a file nor a profile name is set.
+func configuredProfile(d internal.Data) (*profile.Profile, error) {
+	path := firstNonEmpty(stringValue(d, "config_file"), os.Getenv("EXA_CONFIG_FILE"))
+	name := firstNonEmpty(stringValue(d, "profile"), os.Getenv("EXA_PROFILE"))
+	if path == "" && name == "" {
+		return &profile.Profile{}, nil
+	}
+
+	if path == "" {
+		var err error
+		path, err = profile.DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return profile.Load(path, name)
+}
+
+// configuredHosts collects hosts from hosts, ip, host, EXAHOST or
+// the profile (in that order) and validates all host ranges
+func configuredHosts(d internal.Data, p *profile.Profile) (exaprovider.Hosts, error) {
+	var entries []string
+	if l, ok := d.Get("hosts").([]interface{}); ok {
+		for _, e := range l {
+			entry, _ := e.(string)
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) == 0 {
+		host := firstNonEmpty(stringValue(d, "ip"), stringValue(d, "host"), os.Getenv("EXAHOST"), p.Host)
+		if host == "" {
+			return exaprovider.Hosts{}, nil
+		}
+		entries = append(entries, host)
+	}
+
+	hosts, err := exaprovider.ParseHosts(entries...)
+	if err != nil {
+		return exaprovider.Hosts{}, fmt.Errorf("invalid host configuration: %w", err)
+	}
+	return hosts, nil
+}
+
+func stringValue(d internal.Data, name string) string {
+	s, _ := d.Get(name).(string)
+	return s
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
 }
@@ -0,0 +1,185 @@
+package resourceprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+)
+
+func TestProviderConfigureProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles")
+	err := os.WriteFile(path, []byte(`[prod]
+host = 10.0.0.11..14
+port = 8564
+username = admin
+password = secret
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("EXAHOST", "")
+	t.Setenv("EXAUID", "envuser")
+	t.Setenv("EXAPWD", "")
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"config_file": path,
+			"profile":     "prod",
+			"password":    "configured",
+		},
+	}
+
+	m, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	conf := m.(*exaprovider.Client).Conf()
+	if conf.Host != "10.0.0.11..14" {
+		t.Errorf("Unexpected host: %s", conf.Host)
+	}
+	if conf.Port != 8564 {
+		t.Errorf("Unexpected port: %d", conf.Port)
+	}
+	if conf.User != "envuser" {
+		t.Errorf("Expected environment to win over profile: %s", conf.User)
+	}
+	if conf.Password != "configured" {
+		t.Errorf("Expected configuration to win over profile: %s", conf.Password)
+	}
+}
+
+func TestProviderConfigureProfileToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles")
+	err := os.WriteFile(path, []byte(`{"default": {"host": "localhost", "username": "sso", "access_token": "eyJhbGciOi"}}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("EXAHOST", "")
+	t.Setenv("EXAUID", "")
+	t.Setenv("EXAPWD", "")
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"config_file": path,
+			"profile":     "default",
+		},
+	}
+
+	_, err = providerConfigure(d)
+	if err == nil {
+		t.Fatal("Expected error for unsupported access token of profile")
+	}
+
+	d.Values["password"] = "configured"
+	m, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	c := m.(*exaprovider.Client)
+	if c.Conf().Password != "configured" {
+		t.Errorf("Expected configured password to replace token of profile: %s", c.Conf().Password)
+	}
+
+	delete(d.Values, "password")
+	d.Values["refresh_token"] = "eyJhbGciOi"
+	_, err = providerConfigure(d)
+	if err == nil {
+		t.Fatal("Expected error for unsupported refresh token")
+	}
+}
+
+func TestProviderConfigureInvalidHostRange(t *testing.T) {
+	t.Setenv("EXAHOST", "")
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"host": "10.0.0.14..11",
+		},
+	}
+
+	_, err := providerConfigure(d)
+	if err == nil {
+		t.Fatal("Expected error for invalid host range")
+	}
+}
+
+func TestProviderConfigureIPv6(t *testing.T) {
+	t.Setenv("EXAHOST", "")
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"host": "[fd00::11]:8563",
+		},
+	}
+
+	_, err := providerConfigure(d)
+	if err == nil {
+		t.Fatal("Expected error for IPv6 address")
+	}
+}
+
+func TestProviderConfigureDefaults(t *testing.T) {
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"host":           "localhost",
+			"default_schema": "DEV",
+			"object_prefix":  "DEV_",
+		},
+	}
+
+	m, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	defaults := m.(*exaprovider.Client).Defaults
+	if defaults.Schema != "DEV" {
+		t.Errorf("Unexpected default schema: %s", defaults.Schema)
+	}
+	if defaults.ObjectPrefix != "DEV_" {
+		t.Errorf("Unexpected object prefix: %s", defaults.ObjectPrefix)
+	}
+}
+
+func TestProviderConfigureSession(t *testing.T) {
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"host":               "localhost",
+			"query_timeout":      60,
+			"resultset_max_rows": 100,
+			"session_parameters": map[string]interface{}{
+				"TIME_ZONE": "UTC",
+			},
+		},
+	}
+
+	m, err := providerConfigure(d)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	c := m.(*exaprovider.Client)
+	if c.Session.QueryTimeout != 60 {
+		t.Errorf("Unexpected query timeout: %d", c.Session.QueryTimeout)
+	}
+	if c.Session.Parameters["TIME_ZONE"] != "UTC" {
+		t.Errorf("Unexpected session parameters: %#v", c.Session.Parameters)
+	}
+	if c.Conf().ResultSetMaxRows != 100 {
+		t.Errorf("Unexpected resultset max rows: %d", c.Conf().ResultSetMaxRows)
+	}
+
+	d.Values["session_parameters"] = map[string]interface{}{
+		"TIME_ZONE; DROP": "UTC",
+	}
+	_, err = providerConfigure(d)
+	if err == nil {
+		t.Fatal("Expected error for invalid session parameter")
+	}
+}
@@ -3,37 +3,36 @@ package resourceprovider
 import (
 	"context"
 
-	"github.com/abergmeier/terraform-provider-exasol/internal/datasources"
-	dconn "github.com/abergmeier/terraform-provider-exasol/internal/datasources/connection"
-	drole "github.com/abergmeier/terraform-provider-exasol/internal/datasources/role"
-	dtable "github.com/abergmeier/terraform-provider-exasol/internal/datasources/table"
-	dview "github.com/abergmeier/terraform-provider-exasol/internal/datasources/view"
 	"github.com/abergmeier/terraform-provider-exasol/internal/resources"
 	rconn "github.com/abergmeier/terraform-provider-exasol/internal/resources/connection"
+	rconngrant "github.com/abergmeier/terraform-provider-exasol/internal/resources/connectiongrant"
+	rimpgrant "github.com/abergmeier/terraform-provider-exasol/internal/resources/impersonationgrant"
+	rpwpolicy "github.com/abergmeier/terraform-provider-exasol/internal/resources/passwordpolicy"
 	rrole "github.com/abergmeier/terraform-provider-exasol/internal/resources/role"
+	rsql "github.com/abergmeier/terraform-provider-exasol/internal/resources/sqlscript"
+	rsysparam "github.com/abergmeier/terraform-provider-exasol/internal/resources/systemparameter"
 	rtable "github.com/abergmeier/terraform-provider-exasol/internal/resources/table"
 	ruser "github.com/abergmeier/terraform-provider-exasol/internal/resources/user"
 	rview "github.com/abergmeier/terraform-provider-exasol/internal/resources/view"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
 	provider := &schema.Provider{
-		DataSourcesMap: map[string]*schema.Resource{
-			"exasol_connection":      dconn.Resource(),
-			"exasol_physical_schema": datasources.PhysicalSchema(),
-			"exasol_role":            drole.Resource(),
-			"exasol_table":           dtable.Resource(),
-			"exasol_view":            dview.Resource(),
-		},
 		ResourcesMap: map[string]*schema.Resource{
-			"exasol_connection":      rconn.Resource(),
-			"exasol_physical_schema": resources.PhysicalSchema(),
-			"exasol_role":            rrole.Resource(),
-			"exasol_table":           rtable.Resource(),
-			"exasol_user":            ruser.Resource(),
-			"exasol_view":            rview.Resource(),
+			"exasol_connection":          rconn.Resource(),
+			"exasol_connection_grant":    rconngrant.Resource(),
+			"exasol_impersonation_grant": rimpgrant.Resource(),
+			"exasol_password_policy":     rpwpolicy.Resource(),
+			"exasol_physical_schema":     resources.PhysicalSchema(),
+			"exasol_role":                rrole.Resource(),
+			"exasol_sql":                 rsql.Resource(),
+			"exasol_system_parameter":    rsysparam.Resource(),
+			"exasol_table":               rtable.Resource(),
+			"exasol_user":                ruser.Resource(),
+			"exasol_view":                rview.Resource(),
 		},
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -45,9 +44,33 @@ func Provider() *schema.Provider {
 				Optional:  true,
 				Sensitive: true,
 			},
+			"access_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password", "refresh_token"},
+				Description:   "OpenID access token used instead of password. Not supported by the bundled driver yet, configuring it fails",
+			},
+			"refresh_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password", "access_token"},
+				Description:   "OpenID refresh token used instead of password. Not supported by the bundled driver yet, configuring it fails",
+			},
 			"host": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"hosts"},
+				Description:   "Host, comma separated hosts or host range (e.g. 10.0.0.11..14) of the Exasol cluster",
+			},
+			"hosts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Hosts or host ranges of the Exasol cluster. Unreachable nodes are skipped on connect.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"ip": {
 				Type:       schema.TypeString,
@@ -55,18 +78,64 @@ func Provider() *schema.Provider {
 				Deprecated: "Attribute ip is deprecated. Use host instead.",
 			},
 			"port": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  8563,
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Port of the Exasol cluster. Defaults to 8563.",
 			},
 			"dsn": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"username", "password", "host", "ip"},
-				ExactlyOneOf:  []string{"host", "ip", "dsn"},
+				ConflictsWith: []string{"username", "password", "access_token", "refresh_token", "host", "hosts", "ip", "port", "config_file", "profile"},
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to an INI or JSON profile file. Defaults to ~/.exasol/profiles when profile is set.",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the profile in config_file to read connection settings from",
+			},
+			"default_schema": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Schema used by Tables and Views that omit schema. Changing it replaces them.",
+			},
+			"object_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Prefix prepended to names of Tables and Views. Changing it replaces them. Not applied to data sources.",
+			},
+			"query_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Timeout in seconds for every statement. 0 keeps the database default.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"fetch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Amount of data in KiB fetched per round trip",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"resultset_max_rows": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of rows in a result set. 0 means unlimited.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"session_parameters": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Parameters set via ALTER SESSION SET for every Session (e.g. NLS_DATE_FORMAT, TIME_ZONE)",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 		},
 	}
+	withSubsystems(provider.ResourcesMap, "")
 	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		// Shameless plug from https://github.com/terraform-providers/terraform-provider-aws/blob/d51784148586f605ab30ecea268e80fe83d415a9/aws/provider.go
 		terraformVersion := provider.TerraformVersion
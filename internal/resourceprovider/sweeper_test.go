@@ -0,0 +1,164 @@
+package resourceprovider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// testPatterns match the names of objects created by tests. Tests
+// name objects t.Name() + "_" + nameSuffix, where nameSuffix consists of
+// 10 random alphanumeric characters, optionally followed by a further
+// "_" separated part. Shared schemas are named <package>_TestMain.
+// Both are matched uppercased so objects of users merely starting with
+// TEST are left alone.
+var testPatterns = []string{
+	`^TEST[A-Z0-9_]*_[A-Z0-9]{10}(_[A-Z0-9_]+)?$`,
+	`^(DATASOURCES|RESOURCES)(_[A-Z]+)*_TESTMAIN$`,
+}
+
+// Sweepers are chained so that objects are dropped in dependency order
+// (views, tables, schemas, connections, users, roles).
+// exasol_sql has no object of its own to sweep.
+func init() {
+	resource.AddTestSweepers("exasol_view", &resource.Sweeper{
+		Name: "exasol_view",
+		F: sweepFunc(
+			"SELECT VIEW_SCHEMA, VIEW_NAME FROM SYS.EXA_DBA_VIEWS WHERE "+matchTestNames("VIEW_SCHEMA", "VIEW_NAME"),
+			"DROP VIEW %s",
+		),
+	})
+	resource.AddTestSweepers("exasol_table", &resource.Sweeper{
+		Name:         "exasol_table",
+		Dependencies: []string{"exasol_view"},
+		F: sweepFunc(
+			"SELECT TABLE_SCHEMA, TABLE_NAME FROM SYS.EXA_DBA_TABLES WHERE "+matchTestNames("TABLE_SCHEMA", "TABLE_NAME"),
+			"DROP TABLE %s CASCADE CONSTRAINTS",
+		),
+	})
+	resource.AddTestSweepers("exasol_physical_schema", &resource.Sweeper{
+		Name:         "exasol_physical_schema",
+		Dependencies: []string{"exasol_table"},
+		F: sweepFunc(
+			"SELECT SCHEMA_NAME FROM SYS.EXA_SCHEMAS WHERE "+matchTestNames("SCHEMA_NAME"),
+			"DROP SCHEMA %s CASCADE",
+		),
+	})
+	resource.AddTestSweepers("exasol_connection", &resource.Sweeper{
+		Name:         "exasol_connection",
+		Dependencies: []string{"exasol_physical_schema"},
+		F: sweepFunc(
+			"SELECT CONNECTION_NAME FROM SYS.EXA_DBA_CONNECTIONS WHERE "+matchTestNames("CONNECTION_NAME"),
+			"DROP CONNECTION %s",
+		),
+	})
+	resource.AddTestSweepers("exasol_user", &resource.Sweeper{
+		Name:         "exasol_user",
+		Dependencies: []string{"exasol_connection"},
+		F: sweepFunc(
+			"SELECT USER_NAME FROM SYS.EXA_DBA_USERS WHERE "+matchTestNames("USER_NAME"),
+			"DROP USER %s CASCADE",
+		),
+	})
+	resource.AddTestSweepers("exasol_role", &resource.Sweeper{
+		Name:         "exasol_role",
+		Dependencies: []string{"exasol_user"},
+		F: sweepFunc(
+			"SELECT ROLE_NAME FROM SYS.EXA_DBA_ROLES WHERE "+matchTestNames("ROLE_NAME"),
+			"DROP ROLE %s CASCADE",
+		),
+	})
+}
+
+// matchTestNames renders a condition matching any of columns against testPatterns
+func matchTestNames(columns ...string) string {
+	conds := []string{}
+	for _, col := range columns {
+		for _, p := range testPatterns {
+			conds = append(conds, fmt.Sprintf("REGEXP_LIKE(UPPER(%s), '%s')", col, p))
+		}
+	}
+	return "(" + strings.Join(conds, " OR ") + ")"
+}
+
+// sweepFunc drops every object returned by query. The name columns
+// are quoted and joined by dots into the argument of stmt.
+func sweepFunc(query, stmt string) resource.SweeperFunc {
+	return func(region string) error {
+		ctx := context.Background()
+		c := testclient.MustCreateTestClient()
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+
+		rows, err := locked.Tx.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		names := []string{}
+		for rows.Next() {
+			cols, err := rows.Columns()
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			parts := make([]string, len(cols))
+			dest := make([]interface{}, len(cols))
+			for i := range parts {
+				dest[i] = &parts[i]
+			}
+			err = rows.Scan(dest...)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			for i, p := range parts {
+				parts[i] = `"` + strings.ReplaceAll(p, `"`, `""`) + `"`
+			}
+			names = append(names, strings.Join(parts, "."))
+		}
+		rows.Close()
+		if rows.Err() != nil {
+			return rows.Err()
+		}
+
+		for _, name := range names {
+			_, err = locked.Tx.ExecContext(ctx, fmt.Sprintf(stmt, name))
+			if err != nil {
+				return fmt.Errorf("sweeping %s failed: %w", name, err)
+			}
+		}
+		return locked.Tx.Commit()
+	}
+}
+
+func TestTestPatterns(t *testing.T) {
+	tests := map[string]bool{
+		"TESTROLE_AB12CD34EF":              true,
+		"TESTUSERIMPORT_AB12CD34EF":        true,
+		"TESTGRANT_SUB_AB12CD34EF_GRANTEE": true,
+		"DATASOURCES_TABLES_TESTMAIN":      true,
+		"RESOURCES_VIEW_TEST_TESTMAIN":     true,
+		"TEST":                             false,
+		"TEST_CUSTOMERS":                   false,
+		"TESTING_AB12CD34E":                false,
+		"PROD_TESTMAIN":                    false,
+		"CUSTOMERS_TESTMAIN_BACKUP":        false,
+	}
+
+	for name, expected := range tests {
+		matched := false
+		for _, p := range testPatterns {
+			if regexp.MustCompile(p).MatchString(name) {
+				matched = true
+			}
+		}
+		if matched != expected {
+			t.Errorf("Unexpected match of %s: %t", name, matched)
+		}
+	}
+}
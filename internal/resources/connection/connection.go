@@ -13,36 +13,51 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Resource for Exasol Connection
 func Resource() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of connection",
+		},
+		"to": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "Where connection points to. Rendered when using a typed block",
+			ExactlyOneOf: append([]string{"to"}, targetKinds...),
+		},
+		"username": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "User to use for connection",
+		},
+		"password": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Password to use for connection",
+			Sensitive:   true,
+		},
+	}
+	targetSchemas(s)
+	credentialSchemas(s)
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of connection",
-			},
-			"to": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Where connection points to",
-			},
-			"username": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "User to use for connection",
-			},
-			"password": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Password to use for connection",
-				Sensitive:   true,
+		Schema:        s,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resource.UpgradeGlobalIDV0,
 			},
 		},
 		CreateContext: createConnection,
@@ -52,6 +67,12 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importConnection,
 		},
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf("to", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChanges(targetKinds...)
+			}),
+			customizePasswordHash,
+		),
 	}
 
 }
@@ -61,11 +82,7 @@ func readConnection(ctx context.Context, d *schema.ResourceData, meta interface{
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
 	err := readConnectionData(ctx, d, locked.Tx)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
+	return diag.FromErr(resource.RemoveIfNotFound(ctx, d, err))
 }
 
 func readConnectionData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
@@ -74,7 +91,41 @@ func readConnectionData(ctx context.Context, d internal.Data, tx *sql.Tx) error
 	if errors.Is(err, argument.ErrorEmptyName) {
 		return fmt.Errorf("empty name not allowed for Connection (id: %s)", d.Id())
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	err = readTarget(d)
+	if err != nil {
+		return err
+	}
+	return verifyConnection(ctx, d, tx)
+}
+
+// readTarget parses `to` into the typed block in use so that
+// changes outside of Terraform are detected
+func readTarget(d internal.Data) error {
+	kind, old := resourceTarget(d)
+	if kind == "" {
+		return nil
+	}
+	t := targets[kind]
+	block, err := t.parse(d.Get("to").(string))
+	if err != nil {
+		// Drifted to another kind. Removing the block forces an update.
+		return d.Set(kind, []interface{}{})
+	}
+	if t.user != "" {
+		if _, ok := block[t.user]; !ok {
+			block[t.user], _ = d.Get("username").(string)
+		}
+		// Secrets cannot be read back
+		block[t.secret] = old[t.secret]
+		err = d.Set("username", "")
+		if err != nil {
+			return err
+		}
+	}
+	return d.Set(kind, []interface{}{block})
 }
 
 func createConnection(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -101,22 +152,33 @@ func createConnectionData(d internal.Data, tx *sql.Tx) error {
 	if err != nil {
 		return err
 	}
-	to := d.Get("to").(string)
+	to, err := resourceTo(d)
+	if err != nil {
+		return err
+	}
+	err = d.Set("to", to)
+	if err != nil {
+		return err
+	}
 
 	user := resourceUser(d)
 	identifiedBy := resourceIdentifiedBy(d)
 
 	if user == "" {
-		stmt := fmt.Sprintf("CREATE CONNECTION %s TO '%s'", name, to)
+		stmt := fmt.Sprintf("CREATE CONNECTION %s TO %s", name, db.StringLiteral(to))
 		_, err = tx.Exec(stmt)
 	} else if identifiedBy == "" {
-		stmt := fmt.Sprintf("CREATE CONNECTION %s TO '%s' USER '%s'", name, to, user)
+		stmt := fmt.Sprintf("CREATE CONNECTION %s TO %s USER %s", name, db.StringLiteral(to), db.StringLiteral(user))
 		_, err = tx.Exec(stmt)
 	} else {
-		stmt := fmt.Sprintf("CREATE CONNECTION %s TO '%s' USER '%s' IDENTIFIED BY '%s'", name, to, user, identifiedBy)
+		stmt := fmt.Sprintf("CREATE CONNECTION %s TO %s USER %s IDENTIFIED BY %s", name, db.StringLiteral(to), db.StringLiteral(user), db.StringLiteral(identifiedBy))
 		_, err = tx.Exec(stmt)
 	}
 
+	if err != nil {
+		return err
+	}
+	err = setPasswordHash(d)
 	if err != nil {
 		return err
 	}
@@ -219,24 +281,27 @@ func updateConnectionData(d internal.Data, tx *sql.Tx) error {
 	if err != nil {
 		return err
 	}
+	err = d.Set("to", to)
+	if err != nil {
+		return err
+	}
 	user := resourceUser(d)
 	identifiedBy := resourceIdentifiedBy(d)
 
 	if user == "" {
-		stmt := fmt.Sprintf("ALTER CONNECTION %s TO '%s'", name, to)
-		_, err := tx.Exec(stmt)
-		return err
+		stmt := fmt.Sprintf("ALTER CONNECTION %s TO %s", name, db.StringLiteral(to))
+		_, err = tx.Exec(stmt)
+	} else if identifiedBy == "" {
+		stmt := fmt.Sprintf("ALTER CONNECTION %s TO %s USER %s", name, db.StringLiteral(to), db.StringLiteral(user))
+		_, err = tx.Exec(stmt)
+	} else {
+		stmt := fmt.Sprintf("ALTER CONNECTION %s TO %s USER %s IDENTIFIED BY %s", name, db.StringLiteral(to), db.StringLiteral(user), db.StringLiteral(identifiedBy))
+		_, err = tx.Exec(stmt)
 	}
-
-	if identifiedBy == "" {
-		stmt := fmt.Sprintf("ALTER CONNECTION %s TO '%s' USER '%s'", name, to, user)
-		_, err := tx.Exec(stmt)
+	if err != nil {
 		return err
 	}
-
-	stmt := fmt.Sprintf("ALTER CONNECTION %s TO '%s' USER '%s' IDENTIFIED BY '%s'", name, to, user, identifiedBy)
-	_, err = tx.Exec(stmt)
-	return err
+	return setPasswordHash(d)
 }
 
 func Exists(ctx context.Context, tx *sql.Tx, name string) (bool, error) {
@@ -248,14 +313,23 @@ func Exists(ctx context.Context, tx *sql.Tx, name string) (bool, error) {
 }
 
 func resourceTo(d internal.Data) (string, error) {
-	to := d.Get("to").(string)
+	kind, block := resourceTarget(d)
+	if kind != "" {
+		return targets[kind].render(block)
+	}
+	to, _ := d.Get("to").(string)
 	if to == "" {
 		return "", fmt.Errorf("empty attribute `to` for `%s`", d)
 	}
 	return to, nil
 }
 
-func resourceUser(d internal.Data) string {
+func resourceUser(d getter) string {
+	kind, block := resourceTarget(d)
+	if kind != "" && targets[kind].user != "" {
+		user, _ := block[targets[kind].user].(string)
+		return user
+	}
 	user := d.Get("username")
 	if user == nil {
 		return ""
@@ -263,7 +337,12 @@ func resourceUser(d internal.Data) string {
 	return user.(string)
 }
 
-func resourceIdentifiedBy(d internal.Data) string {
+func resourceIdentifiedBy(d getter) string {
+	kind, block := resourceTarget(d)
+	if kind != "" && targets[kind].secret != "" {
+		secret, _ := block[targets[kind].secret].(string)
+		return secret
+	}
 	pwd := d.Get("password")
 	if pwd == nil {
 		return ""
@@ -0,0 +1,159 @@
+package connection
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/logging"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// getter is the part of internal.Data also offered by schema.ResourceDiff
+type getter interface {
+	Get(name string) interface{}
+}
+
+func credentialSchemas(s map[string]*schema.Schema) {
+	s["password_hash"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Salted hash of the applied password. Used for detecting changes since Exasol does not return passwords",
+	}
+	s["password_version"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Changing the version applies the password again, e.g. after rotating it in a vault",
+	}
+	s["keepers"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Arbitrary values that apply the password again when changed",
+	}
+	s["verify"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Probes the connection with IMPORT on every read. Failing probes cause the credentials to be applied again",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Source type of IMPORT: EXA, ORA, JDBC or CSV",
+					ValidateFunc: validation.StringInSlice([]string{"EXA", "ORA", "JDBC", "CSV"}, false),
+				},
+				"statement": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "SELECT 1",
+					Description: "Statement executed at the source for types other than CSV",
+				},
+				"file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "File read for type CSV",
+				},
+			},
+		},
+	}
+}
+
+// passwordHash renders a hash of password with a new random salt
+func passwordHash(password string) (string, error) {
+	salt := make([]byte, 16)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+	return saltedHash(hex.EncodeToString(salt), password)
+}
+
+func saltedHash(salt, password string) (string, error) {
+	sum, err := internal.HashStrings(salt, password)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s", salt, hex.EncodeToString(sum)), nil
+}
+
+// matchesPasswordHash checks whether password was used for rendering hash
+func matchesPasswordHash(hash, password string) bool {
+	parts := strings.SplitN(hash, ":", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	expected, err := saltedHash(parts[0], password)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(hash)) == 1
+}
+
+// setPasswordHash stores the hash of the applied password
+func setPasswordHash(d internal.Data) error {
+	hash, err := passwordHash(resourceIdentifiedBy(d))
+	if err != nil {
+		return err
+	}
+	return d.Set("password_hash", hash)
+}
+
+func customizePasswordHash(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChanges("password_version", "keepers") {
+		return d.SetNewComputed("password_hash")
+	}
+	hash, _ := d.Get("password_hash").(string)
+	if !matchesPasswordHash(hash, resourceIdentifiedBy(d)) {
+		return d.SetNewComputed("password_hash")
+	}
+	return nil
+}
+
+// verifyConnection probes the connection if requested. On failure
+// the hash is reset so that the next plan applies the credentials again.
+func verifyConnection(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	blocks, _ := d.Get("verify").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+	stmt := probeStatement(name, blocks[0].(map[string]interface{}))
+	rows, err := tx.QueryContext(ctx, stmt)
+	if err == nil {
+		return rows.Close()
+	}
+	logging.Warn(ctx, "Verifying Connection failed", map[string]interface{}{
+		"connection": name,
+		"error":      err.Error(),
+	})
+	return d.Set("password_hash", "")
+}
+
+func probeStatement(name string, verify map[string]interface{}) string {
+	typ := verify["type"].(string)
+	if typ == "CSV" {
+		file, _ := verify["file"].(string)
+		return fmt.Sprintf("SELECT * FROM (IMPORT INTO (PROBE VARCHAR(2000000)) FROM CSV AT %s FILE %s) LIMIT 1", name, db.StringLiteral(file))
+	}
+	statement, _ := verify["statement"].(string)
+	if statement == "" {
+		statement = "SELECT 1"
+	}
+	return fmt.Sprintf("SELECT * FROM (IMPORT FROM %s AT %s STATEMENT %s) LIMIT 1", typ, name, db.StringLiteral(statement))
+}
@@ -0,0 +1,129 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestPasswordHash(t *testing.T) {
+	first, err := passwordHash("secret")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	second, err := passwordHash("secret")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if first == second {
+		t.Fatal("Expected different salts")
+	}
+	if !matchesPasswordHash(first, "secret") || !matchesPasswordHash(second, "secret") {
+		t.Fatal("Expected hashes to match password")
+	}
+	if matchesPasswordHash(first, "other") {
+		t.Fatal("Expected hash not to match other password")
+	}
+	if matchesPasswordHash("", "") {
+		t.Fatal("Expected empty hash not to match")
+	}
+}
+
+func TestCustomizePasswordHash(t *testing.T) {
+	applied, err := passwordHash("secret")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	state := func(hash string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "FOO",
+			Attributes: map[string]string{
+				"id":            "FOO",
+				"name":          "foo",
+				"to":            "ftp://example.com",
+				"username":      "bar",
+				"password":      "secret",
+				"password_hash": hash,
+			},
+		}
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "foo",
+		"to":       "ftp://example.com",
+		"username": "bar",
+		"password": "secret",
+	})
+
+	diff, err := Resource().Diff(context.TODO(), state(applied), config, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if diff != nil && diff.Attributes["password_hash"] != nil {
+		t.Fatalf("Unexpected change of password_hash: %#v", diff.Attributes["password_hash"])
+	}
+
+	// Reset by a failed verify probe during refresh
+	diff, err = Resource().Diff(context.TODO(), state(""), config, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if diff == nil || diff.Attributes["password_hash"] == nil || !diff.Attributes["password_hash"].NewComputed {
+		t.Fatalf("Expected password to be applied again: %#v", diff)
+	}
+}
+
+func TestVerifyConnectionGolden(t *testing.T) {
+	tests := map[string]struct {
+		verify map[string]interface{}
+		err    error
+	}{
+		"verify_jdbc": {
+			verify: map[string]interface{}{
+				"type":      "JDBC",
+				"statement": "SELECT 'x' FROM dual",
+				"file":      "",
+			},
+		},
+		"verify_csv_failed": {
+			verify: map[string]interface{}{
+				"type":      "CSV",
+				"statement": "SELECT 1",
+				"file":      "probe.csv",
+			},
+			err: errors.New("access denied"),
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			rec, tx := sqlrecorder.New(t)
+			if test.err != nil {
+				rec.AddError("SELECT * FROM (IMPORT", test.err)
+			}
+			d := &internal.TestData{
+				Values: map[string]interface{}{
+					"name":          "foo",
+					"password_hash": "salt:hash",
+					"verify":        []interface{}{test.verify},
+				},
+			}
+			err := verifyConnection(context.TODO(), d, tx)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			hash := d.Get("password_hash")
+			if test.err == nil && hash != "salt:hash" {
+				t.Fatalf("Unexpected hash after successful probe: %#v", hash)
+			}
+			if test.err != nil && hash != "" {
+				t.Fatalf("Expected hash reset after failed probe: %#v", hash)
+			}
+			rec.AssertGolden(t, name)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+)
+
+func TestCreateConnectionDataGolden(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"create_to": {
+			"name": "foo",
+			"to":   "ftp://example.com",
+		},
+		"create_user": {
+			"name":     "foo",
+			"to":       "ftp://example.com",
+			"username": "bar",
+		},
+		"create_identified": {
+			"name":     "foo",
+			"to":       "ftp://example.com",
+			"username": "bar",
+			"password": "secret",
+		},
+		"create_escaped": {
+			"name":     "foo",
+			"to":       "ftp://example.com/it's",
+			"username": "o'brien",
+			"password": "se'cret",
+		},
+		"create_s3": {
+			"name": "foo",
+			"s3": []interface{}{
+				map[string]interface{}{
+					"bucket":     "my-bucket",
+					"region":     "eu-west-1",
+					"access_key": "AKIA",
+					"secret_key": "secret",
+				},
+			},
+		},
+		"create_azure": {
+			"name": "foo",
+			"azure": []interface{}{
+				map[string]interface{}{
+					"account":     "myaccount",
+					"container":   "data",
+					"account_key": "secret",
+				},
+			},
+		},
+		"create_exasol": {
+			"name":     "foo",
+			"username": "bar",
+			"password": "secret",
+			"exasol": []interface{}{
+				map[string]interface{}{
+					"host_range":  "10.0.0.11..14",
+					"port":        8563,
+					"fingerprint": "ABC123",
+				},
+			},
+		},
+	}
+
+	for name, values := range tests {
+		values := values
+		t.Run(name, func(t *testing.T) {
+			rec, tx := sqlrecorder.New(t)
+			err := createConnectionData(&internal.TestData{Values: values}, tx)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			rec.AssertGolden(t, name)
+		})
+	}
+}
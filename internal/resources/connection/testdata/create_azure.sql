@@ -0,0 +1 @@
+CREATE CONNECTION foo TO 'https://myaccount.blob.core.windows.net/data' USER 'myaccount' IDENTIFIED BY 'secret';
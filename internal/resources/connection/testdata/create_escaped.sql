@@ -0,0 +1 @@
+CREATE CONNECTION foo TO 'ftp://example.com/it''s' USER 'o''brien' IDENTIFIED BY 'se''cret';
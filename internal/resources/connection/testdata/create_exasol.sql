@@ -0,0 +1 @@
+CREATE CONNECTION foo TO '10.0.0.11..14/ABC123:8563' USER 'bar' IDENTIFIED BY 'secret';
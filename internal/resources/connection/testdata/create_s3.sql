@@ -0,0 +1 @@
+CREATE CONNECTION foo TO 'https://my-bucket.s3.eu-west-1.amazonaws.com' USER 'AKIA' IDENTIFIED BY 'secret';
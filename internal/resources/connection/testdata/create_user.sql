@@ -0,0 +1 @@
+CREATE CONNECTION foo TO 'ftp://example.com' USER 'bar';
@@ -0,0 +1 @@
+SELECT * FROM (IMPORT INTO (PROBE VARCHAR(2000000)) FROM CSV AT foo FILE 'probe.csv') LIMIT 1;
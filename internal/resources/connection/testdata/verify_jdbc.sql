@@ -0,0 +1 @@
+SELECT * FROM (IMPORT FROM JDBC AT foo STATEMENT 'SELECT ''x'' FROM dual') LIMIT 1;
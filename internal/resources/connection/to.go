@@ -0,0 +1,381 @@
+package connection
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// target renders and parses the TO string of one kind of Connection
+type target struct {
+	description string
+	schema      map[string]*schema.Schema
+	render      func(block map[string]interface{}) (string, error)
+	parse       func(to string) (map[string]interface{}, error)
+	// user and secret name the attributes of the block passed as USER
+	// and IDENTIFIED BY. Empty if the generic attributes are used.
+	user   string
+	secret string
+}
+
+var (
+	bucketPattern   = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`)
+	regionPattern   = regexp.MustCompile(`^[a-z0-9-]+$`)
+	endpointPattern = regexp.MustCompile(`^(https://)?[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?(:[0-9]+)?$`)
+	s3RegionPattern = regexp.MustCompile(`^s3[.-]([a-z0-9-]+)\.amazonaws\.com$`)
+	azurePattern    = regexp.MustCompile(`^https://([a-z0-9]+)\.blob\.core\.windows\.net/([^/]+)/?$`)
+	gcsPattern      = regexp.MustCompile(`^https://([^/]+)\.storage\.googleapis\.com/?$`)
+	exasolPattern   = regexp.MustCompile(`^([^/:]+)(?:/([^:]+))?(?::([0-9]+))?$`)
+)
+
+var targets = map[string]*target{
+	"s3": {
+		description: "Amazon S3 or compatible bucket",
+		schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of bucket",
+				ValidateFunc: validation.StringMatch(bucketPattern, "invalid bucket name"),
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "AWS region of bucket",
+				ValidateFunc: validation.StringMatch(regionPattern, "invalid region"),
+			},
+			"endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Host of S3 compatible service. Replaces the AWS endpoint",
+				ValidateFunc: validation.StringMatch(endpointPattern, "invalid endpoint: expected host with optional port"),
+			},
+			"access_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Access key id",
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Secret access key",
+			},
+		},
+		render: renderS3,
+		parse:  parseS3,
+		user:   "access_key",
+		secret: "secret_key",
+	},
+	"azure": {
+		description: "Azure Blob Storage container",
+		schema: map[string]*schema.Schema{
+			"account": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of storage account",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9]+$`), "invalid storage account name"),
+			},
+			"container": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of container",
+				ValidateFunc: validation.StringMatch(bucketPattern, "invalid container name"),
+			},
+			"account_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Access key of storage account",
+			},
+		},
+		render: renderAzure,
+		parse:  parseAzure,
+		user:   "account",
+		secret: "account_key",
+	},
+	"gcs": {
+		description: "Google Cloud Storage bucket",
+		schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of bucket",
+				ValidateFunc: validation.StringMatch(bucketPattern, "invalid bucket name"),
+			},
+			"access_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "HMAC access id",
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "HMAC secret",
+			},
+		},
+		render: renderGCS,
+		parse:  parseGCS,
+		user:   "access_key",
+		secret: "secret_key",
+	},
+	"ftp": {
+		description: "FTP or FTPS server",
+		schema: map[string]*schema.Schema{
+			"host": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Host of server",
+				ValidateFunc: validation.StringDoesNotContainAny("/:"),
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Port of server. Omitted if not set",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Directory on server",
+			},
+			"tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Use FTPS",
+			},
+		},
+		render: renderFTP,
+		parse:  parseFTP,
+	},
+	"jdbc": {
+		description: "JDBC data source",
+		schema: map[string]*schema.Schema{
+			"driver_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "JDBC URL e.g. `jdbc:postgresql://host:5432/db`",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^jdbc:[a-zA-Z0-9]+:.+`), "expected JDBC URL starting with `jdbc:<subprotocol>:`"),
+			},
+		},
+		render: renderJDBC,
+		parse:  parseJDBC,
+	},
+	"exasol": {
+		description: "Other Exasol database",
+		schema: map[string]*schema.Schema{
+			"host_range": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Host or host range e.g. `10.0.0.11..14`",
+				ValidateFunc: validation.StringDoesNotContainAny("/:'"),
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8563,
+				Description:  "Port of database",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"fingerprint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Fingerprint of the server certificate",
+				ValidateFunc: validation.StringDoesNotContainAny("/:'"),
+			},
+		},
+		render: renderExasol,
+		parse:  parseExasol,
+	},
+}
+
+// targetKinds lists all typed blocks in a stable order
+var targetKinds = []string{"s3", "azure", "gcs", "ftp", "jdbc", "exasol"}
+
+func targetSchemas(s map[string]*schema.Schema) {
+	exactlyOne := append([]string{"to"}, targetKinds...)
+	for _, kind := range targetKinds {
+		t := targets[kind]
+		s[kind] = &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			Description:  fmt.Sprintf("Renders `to` for %s", t.description),
+			ExactlyOneOf: exactlyOne,
+			Elem: &schema.Resource{
+				Schema: t.schema,
+			},
+		}
+		if t.user != "" {
+			s[kind].ConflictsWith = []string{"username", "password"}
+		}
+	}
+}
+
+// resourceTarget returns the kind and attributes of the configured block.
+// kind is empty if `to` is used directly.
+func resourceTarget(d getter) (string, map[string]interface{}) {
+	for _, kind := range targetKinds {
+		blocks, _ := d.Get(kind).([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+		return kind, blocks[0].(map[string]interface{})
+	}
+	return "", nil
+}
+
+func renderS3(block map[string]interface{}) (string, error) {
+	bucket := block["bucket"].(string)
+	region, _ := block["region"].(string)
+	endpoint, _ := block["endpoint"].(string)
+	switch {
+	case endpoint != "" && region != "":
+		return "", fmt.Errorf("s3 block: only one of `region` and `endpoint` may be set")
+	case endpoint != "":
+		return fmt.Sprintf("https://%s.%s", bucket, strings.TrimPrefix(endpoint, "https://")), nil
+	case region != "":
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, region), nil
+	}
+	return fmt.Sprintf("https://%s.s3.amazonaws.com", bucket), nil
+}
+
+func parseS3(to string) (map[string]interface{}, error) {
+	host := strings.TrimSuffix(strings.TrimPrefix(to, "https://"), "/")
+	if host == to {
+		return nil, fmt.Errorf("expected https URL for S3: %s", to)
+	}
+	parts := strings.SplitN(host, ".", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected bucket in host of S3 URL: %s", to)
+	}
+	bucket, rest := parts[0], parts[1]
+	block := map[string]interface{}{
+		"bucket":   bucket,
+		"region":   "",
+		"endpoint": "",
+	}
+	if rest != "s3.amazonaws.com" {
+		if m := s3RegionPattern.FindStringSubmatch(rest); m != nil {
+			block["region"] = m[1]
+		} else {
+			block["endpoint"] = rest
+		}
+	}
+	return block, nil
+}
+
+func renderAzure(block map[string]interface{}) (string, error) {
+	return fmt.Sprintf("https://%s.blob.core.windows.net/%s", block["account"], block["container"]), nil
+}
+
+func parseAzure(to string) (map[string]interface{}, error) {
+	m := azurePattern.FindStringSubmatch(to)
+	if m == nil {
+		return nil, fmt.Errorf("expected Azure Blob Storage URL: %s", to)
+	}
+	return map[string]interface{}{
+		"account":   m[1],
+		"container": m[2],
+	}, nil
+}
+
+func renderGCS(block map[string]interface{}) (string, error) {
+	return fmt.Sprintf("https://%s.storage.googleapis.com", block["bucket"]), nil
+}
+
+func parseGCS(to string) (map[string]interface{}, error) {
+	m := gcsPattern.FindStringSubmatch(to)
+	if m == nil {
+		return nil, fmt.Errorf("expected Google Cloud Storage URL: %s", to)
+	}
+	return map[string]interface{}{
+		"bucket": m[1],
+	}, nil
+}
+
+func renderFTP(block map[string]interface{}) (string, error) {
+	u := url.URL{
+		Scheme: "ftp",
+		Host:   block["host"].(string),
+	}
+	if tls, _ := block["tls"].(bool); tls {
+		u.Scheme = "ftps"
+	}
+	if port, _ := block["port"].(int); port != 0 {
+		u.Host = fmt.Sprintf("%s:%d", u.Host, port)
+	}
+	if path, _ := block["path"].(string); path != "" {
+		u.Path = "/" + strings.TrimPrefix(path, "/")
+	}
+	return u.String(), nil
+}
+
+func parseFTP(to string) (map[string]interface{}, error) {
+	u, err := url.Parse(to)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "ftp" && u.Scheme != "ftps" {
+		return nil, fmt.Errorf("expected ftp or ftps URL: %s", to)
+	}
+	port := 0
+	if u.Port() != "" {
+		port, err = strconv.Atoi(u.Port())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return map[string]interface{}{
+		"host": u.Hostname(),
+		"port": port,
+		"path": u.Path,
+		"tls":  u.Scheme == "ftps",
+	}, nil
+}
+
+func renderJDBC(block map[string]interface{}) (string, error) {
+	return block["driver_url"].(string), nil
+}
+
+func parseJDBC(to string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(to, "jdbc:") {
+		return nil, fmt.Errorf("expected JDBC URL: %s", to)
+	}
+	return map[string]interface{}{
+		"driver_url": to,
+	}, nil
+}
+
+func renderExasol(block map[string]interface{}) (string, error) {
+	to := block["host_range"].(string)
+	if fingerprint, _ := block["fingerprint"].(string); fingerprint != "" {
+		to += "/" + fingerprint
+	}
+	if port, _ := block["port"].(int); port != 0 {
+		to += fmt.Sprintf(":%d", port)
+	}
+	return to, nil
+}
+
+func parseExasol(to string) (map[string]interface{}, error) {
+	m := exasolPattern.FindStringSubmatch(to)
+	if m == nil {
+		return nil, fmt.Errorf("expected Exasol connection string: %s", to)
+	}
+	port := 8563
+	if m[3] != "" {
+		port, _ = strconv.Atoi(m[3])
+	}
+	return map[string]interface{}{
+		"host_range":  m[1],
+		"fingerprint": m[2],
+		"port":        port,
+	}, nil
+}
@@ -0,0 +1,235 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+)
+
+func TestTargetRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		kind  string
+		block map[string]interface{}
+		to    string
+	}{
+		"s3": {
+			kind: "s3",
+			block: map[string]interface{}{
+				"bucket":   "my-bucket",
+				"region":   "",
+				"endpoint": "",
+			},
+			to: "https://my-bucket.s3.amazonaws.com",
+		},
+		"s3_region": {
+			kind: "s3",
+			block: map[string]interface{}{
+				"bucket":   "my-bucket",
+				"region":   "eu-central-1",
+				"endpoint": "",
+			},
+			to: "https://my-bucket.s3.eu-central-1.amazonaws.com",
+		},
+		"s3_endpoint": {
+			kind: "s3",
+			block: map[string]interface{}{
+				"bucket":   "my-bucket",
+				"region":   "",
+				"endpoint": "minio.example.com:9000",
+			},
+			to: "https://my-bucket.minio.example.com:9000",
+		},
+		"azure": {
+			kind: "azure",
+			block: map[string]interface{}{
+				"account":   "myaccount",
+				"container": "data",
+			},
+			to: "https://myaccount.blob.core.windows.net/data",
+		},
+		"gcs": {
+			kind: "gcs",
+			block: map[string]interface{}{
+				"bucket": "my-bucket",
+			},
+			to: "https://my-bucket.storage.googleapis.com",
+		},
+		"ftp": {
+			kind: "ftp",
+			block: map[string]interface{}{
+				"host": "example.com",
+				"port": 0,
+				"path": "",
+				"tls":  false,
+			},
+			to: "ftp://example.com",
+		},
+		"ftps": {
+			kind: "ftp",
+			block: map[string]interface{}{
+				"host": "example.com",
+				"port": 2121,
+				"path": "/data/in",
+				"tls":  true,
+			},
+			to: "ftps://example.com:2121/data/in",
+		},
+		"jdbc": {
+			kind: "jdbc",
+			block: map[string]interface{}{
+				"driver_url": "jdbc:postgresql://db:5432/foo",
+			},
+			to: "jdbc:postgresql://db:5432/foo",
+		},
+		"exasol": {
+			kind: "exasol",
+			block: map[string]interface{}{
+				"host_range":  "10.0.0.11..14",
+				"port":        8563,
+				"fingerprint": "",
+			},
+			to: "10.0.0.11..14:8563",
+		},
+		"exasol_fingerprint": {
+			kind: "exasol",
+			block: map[string]interface{}{
+				"host_range":  "exa.example.com",
+				"port":        8564,
+				"fingerprint": "ABC123",
+			},
+			to: "exa.example.com/ABC123:8564",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			to, err := targets[test.kind].render(test.block)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			if to != test.to {
+				t.Fatalf("Expected %s but rendered %s", test.to, to)
+			}
+			block, err := targets[test.kind].parse(to)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			if !reflect.DeepEqual(block, test.block) {
+				t.Fatalf("Expected %#v but parsed %#v", test.block, block)
+			}
+		})
+	}
+}
+
+func TestRenderS3RegionAndEndpoint(t *testing.T) {
+	_, err := renderS3(map[string]interface{}{
+		"bucket":   "my-bucket",
+		"region":   "eu-central-1",
+		"endpoint": "minio.example.com",
+	})
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+}
+
+func TestValidateS3Endpoint(t *testing.T) {
+	validate := targets["s3"].schema["endpoint"].ValidateFunc
+	for _, endpoint := range []string{"minio.example.com", "https://minio.example.com", "10.0.0.1:9000"} {
+		_, errs := validate(endpoint, "endpoint")
+		if len(errs) != 0 {
+			t.Errorf("Unexpected errors for %s: %v", endpoint, errs)
+		}
+	}
+	for _, endpoint := range []string{"", "minio.example.com/path", "minio.example.com'", "http://minio.example.com", "minio example"} {
+		_, errs := validate(endpoint, "endpoint")
+		if len(errs) == 0 {
+			t.Errorf("Expected error for %q", endpoint)
+		}
+	}
+}
+
+func TestReadConnectionTarget(t *testing.T) {
+	t.Parallel()
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := exaprovider.TestLock(t, exaClient)
+		defer locked.Unlock()
+
+		d := &internal.TestData{
+			Values: map[string]interface{}{
+				"name": name,
+				"s3": []interface{}{
+					map[string]interface{}{
+						"bucket":     "my-bucket",
+						"region":     "eu-west-1",
+						"endpoint":   "",
+						"access_key": "AKIA",
+						"secret_key": "secret",
+					},
+				},
+			},
+		}
+		err := createConnectionData(d, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+
+		stmt := fmt.Sprintf("ALTER CONNECTION %s TO 'https://my-bucket.s3.us-east-1.amazonaws.com' USER 'OTHER' IDENTIFIED BY 'secret'", name)
+		_, err = locked.Tx.Exec(stmt)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+
+		err = readConnectionData(context.TODO(), d, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		block := d.Get("s3").([]interface{})[0].(map[string]interface{})
+		if block["region"] != "us-east-1" || block["access_key"] != "OTHER" || block["secret_key"] != "secret" {
+			t.Fatalf("Unexpected s3 block: %#v", block)
+		}
+		if d.Get("username") != "" {
+			t.Fatalf("Unexpected username: %#v", d.Get("username"))
+		}
+
+		stmt = fmt.Sprintf("ALTER CONNECTION %s TO 'ftp://example.com'", name)
+		_, err = locked.Tx.Exec(stmt)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+
+		err = readConnectionData(context.TODO(), d, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		if len(d.Get("s3").([]interface{})) != 0 {
+			t.Fatalf("Expected s3 block to be removed: %#v", d.Get("s3"))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -28,7 +28,7 @@ func TestAccExasolConnection_rename(t *testing.T) {
 
 	ps := test.NewDefaultAccProviders()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: ps.Factories,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`%s
@@ -88,7 +88,7 @@ IDENTIFIED BY 'secret'`, dbName)
 	defer tryDeleteConnection()
 
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: test.NewDefaultAccProviders().Factories,
+		ProtoV6ProviderFactories: test.NewDefaultAccProviders().Factories,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: tryDeleteConnection,
@@ -5,8 +5,8 @@ import (
 	"os"
 	"testing"
 
-	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
 	"github.com/exasol/exasol-driver-go"
 )
 
@@ -16,7 +16,7 @@ var (
 )
 
 func init() {
-	exaConf = internal.MustCreateTestConf()
+	exaConf = testclient.MustCreateTestConf()
 }
 
 func TestMain(m *testing.M) {
@@ -0,0 +1,48 @@
+package connectiongrant
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+)
+
+func TestGrantGolden(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"grant": {
+			"connection_name": "conn",
+			"grantee":         "analyst",
+			"script":          "",
+			"admin_option":    false,
+		},
+		"grant_admin": {
+			"connection_name": "conn",
+			"grantee":         "analyst",
+			"script":          "",
+			"admin_option":    true,
+		},
+		"grant_script": {
+			"connection_name": "conn",
+			"grantee":         "analyst",
+			"script":          "udfs.load",
+			"admin_option":    false,
+		},
+	}
+
+	for name, values := range tests {
+		values := values
+		t.Run(name, func(t *testing.T) {
+			rec, tx := sqlrecorder.New(t)
+			d := &internal.TestData{Values: values}
+			err := createData(d, tx)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			err = deleteData(d, tx)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			rec.AssertGolden(t, name)
+		})
+	}
+}
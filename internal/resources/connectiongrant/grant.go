@@ -0,0 +1,248 @@
+package connectiongrant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resource for granting usage of an Exasol Connection. Without script
+// the Connection can be used by grantee everywhere. With script only
+// the given Script may access it.
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"connection_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of Connection. Compared case insensitively",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"grantee": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "User or Role the Connection is granted to. Compared case insensitively",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"script": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Qualified name of Script (SCHEMA.SCRIPT). Restricts access to this Script. Compared case insensitively",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"admin_option": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "Whether grantee may grant the Connection to others",
+				ConflictsWith: []string{"script"},
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+	}
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := createData(d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func createData(d internal.Data, tx *sql.Tx) error {
+	conn, grantee, script, err := arguments(d)
+	if err != nil {
+		return err
+	}
+
+	var stmt string
+	if script == "" {
+		stmt = fmt.Sprintf("GRANT CONNECTION %s TO %s", conn, grantee)
+		if admin, _ := d.Get("admin_option").(bool); admin {
+			stmt += " WITH ADMIN OPTION"
+		}
+	} else {
+		stmt = fmt.Sprintf("GRANT ACCESS ON CONNECTION %s FOR SCRIPT %s TO %s", conn, script, grantee)
+	}
+	_, err = tx.Exec(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(newID(conn, grantee, script))
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	err := readData(ctx, d, locked.Tx)
+	return diag.FromErr(resource.RemoveIfNotFound(ctx, d, err))
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	conn, grantee, script, err := arguments(d)
+	if err != nil {
+		return err
+	}
+
+	if script == "" {
+		r, err := tx.QueryContext(ctx, "SELECT ADMIN_OPTION FROM SYS.EXA_DBA_CONNECTION_PRIVS WHERE UPPER(GRANTED_CONNECTION) = UPPER(?) AND UPPER(GRANTEE) = UPPER(?)", conn, grantee)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		if !r.Next() {
+			return db.NewNotFoundError("CONNECTION GRANT", d.Id())
+		}
+		var admin sql.NullBool
+		err = r.Scan(&admin)
+		if err != nil {
+			return err
+		}
+		return d.Set("admin_option", admin.Bool)
+	}
+
+	scriptSchema, scriptName, err := resource.SplitIDInSchema(script)
+	if err != nil {
+		return err
+	}
+	r, err := tx.QueryContext(ctx, `SELECT PRIVILEGE FROM SYS.EXA_DBA_RESTRICTED_OBJ_PRIVS
+WHERE OBJECT_TYPE = 'CONNECTION' AND UPPER(OBJECT_NAME) = UPPER(?)
+AND FOR_OBJECT_TYPE = 'SCRIPT' AND UPPER(FOR_OBJECT_SCHEMA) = UPPER(?) AND UPPER(FOR_OBJECT_NAME) = UPPER(?)
+AND UPPER(GRANTEE) = UPPER(?) AND PRIVILEGE = 'ACCESS'`, conn, scriptSchema, scriptName, grantee)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if !r.Next() {
+		return db.NewNotFoundError("CONNECTION GRANT", d.Id())
+	}
+	return d.Set("admin_option", false)
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := deleteData(d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func deleteData(d internal.Data, tx *sql.Tx) error {
+	conn, grantee, script, err := arguments(d)
+	if err != nil {
+		return err
+	}
+
+	var stmt string
+	if script == "" {
+		stmt = fmt.Sprintf("REVOKE CONNECTION %s FROM %s", conn, grantee)
+	} else {
+		stmt = fmt.Sprintf("REVOKE ACCESS ON CONNECTION %s FOR SCRIPT %s FROM %s", conn, script, grantee)
+	}
+	_, err = tx.Exec(stmt)
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	err := importData(ctx, d, locked.Tx)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importData expects an id of form connection:grantee[:schema.script]
+func importData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	id := d.Id()
+	if id == "" {
+		return errors.New("import expects id to be set")
+	}
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("import expects id of form connection:grantee[:script]: %s", id)
+	}
+	script := ""
+	if len(parts) == 3 {
+		script = parts[2]
+	}
+	d.SetId(newID(parts[0], parts[1], script))
+
+	err := d.Set("connection_name", strings.ToUpper(parts[0]))
+	if err != nil {
+		return err
+	}
+	err = d.Set("grantee", strings.ToUpper(parts[1]))
+	if err != nil {
+		return err
+	}
+	err = d.Set("script", strings.ToUpper(script))
+	if err != nil {
+		return err
+	}
+	return readData(ctx, d, tx)
+}
+
+func arguments(d internal.Data) (conn, grantee, script string, err error) {
+	conn, _ = d.Get("connection_name").(string)
+	if conn == "" {
+		err = fmt.Errorf("empty connection for %s", d)
+		return
+	}
+	grantee, _ = d.Get("grantee").(string)
+	if grantee == "" {
+		err = fmt.Errorf("empty grantee for %s", d)
+		return
+	}
+	script, _ = d.Get("script").(string)
+	return
+}
+
+func newID(conn, grantee, script string) string {
+	id := strings.ToUpper(conn) + ":" + strings.ToUpper(grantee)
+	if script != "" {
+		id += ":" + strings.ToUpper(script)
+	}
+	return id
+}
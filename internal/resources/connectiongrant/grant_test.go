@@ -0,0 +1,126 @@
+package connectiongrant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConnectionGrant(t *testing.T) {
+	t.Parallel()
+	name := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	for _, script := range []string{"", name + ".SCRIPT"} {
+		err := globallock.RunAndRetryRollbacks(func() error {
+			locked := exaprovider.TestLock(t, exaClient)
+			defer locked.Unlock()
+
+			stmts := []string{
+				fmt.Sprintf("CREATE CONNECTION %s TO 'ftp://example.com'", name),
+				fmt.Sprintf("CREATE ROLE %s", name),
+			}
+			for _, stmt := range stmts {
+				_, err := locked.Tx.Exec(stmt)
+				if err != nil {
+					if globallock.IsRollbackError(err) {
+						return err
+					}
+					t.Fatal("Unexpected error:", err)
+				}
+			}
+
+			create := &internal.TestData{
+				Values: map[string]interface{}{
+					"connection_name": name,
+					"grantee":         name,
+					"script":          script,
+					"admin_option":    script == "",
+				},
+			}
+			err := createData(create, locked.Tx)
+			if err != nil {
+				if globallock.IsRollbackError(err) {
+					return err
+				}
+				t.Fatal("Unexpected error:", err)
+			}
+
+			imp := &internal.TestData{}
+			imp.SetId(strings.ToLower(create.Id()))
+			err = importData(context.TODO(), imp, locked.Tx)
+			if err != nil {
+				if globallock.IsRollbackError(err) {
+					return err
+				}
+				t.Fatal("Unexpected error:", err)
+			}
+			if imp.Id() != create.Id() || imp.Get("script") != script {
+				t.Fatalf("Unexpected import: %s %#v", imp.Id(), imp.Get("script"))
+			}
+			if imp.Get("admin_option") != (script == "") {
+				t.Fatalf("Unexpected admin_option: %#v", imp.Get("admin_option"))
+			}
+
+			err = deleteData(create, locked.Tx)
+			if err != nil {
+				if globallock.IsRollbackError(err) {
+					return err
+				}
+				t.Fatal("Unexpected error:", err)
+			}
+			err = readData(context.TODO(), imp, locked.Tx)
+			if !db.IsNotFound(err) {
+				if globallock.IsRollbackError(err) {
+					return err
+				}
+				t.Fatal("Expected not found error:", err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestImportInvalidID(t *testing.T) {
+	d := &internal.TestData{}
+	d.SetId("conn")
+	err := importData(context.TODO(), d, nil)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+}
+
+func TestImportedCaseIsKept(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "CONN:ROLE:S.SCRIPT",
+		Attributes: map[string]string{
+			"id":              "CONN:ROLE:S.SCRIPT",
+			"connection_name": "CONN",
+			"grantee":         "ROLE",
+			"script":          "S.SCRIPT",
+			"admin_option":    "false",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"connection_name": "conn",
+		"grantee":         "Role",
+		"script":          "s.script",
+	})
+
+	diff, err := Resource().Diff(context.TODO(), state, config, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("Unexpected diff for different case: %#v", diff.Attributes)
+	}
+}
@@ -0,0 +1,2 @@
+GRANT CONNECTION conn TO analyst;
+REVOKE CONNECTION conn FROM analyst;
@@ -0,0 +1,2 @@
+GRANT CONNECTION conn TO analyst WITH ADMIN OPTION;
+REVOKE CONNECTION conn FROM analyst;
@@ -0,0 +1,2 @@
+GRANT ACCESS ON CONNECTION conn FOR SCRIPT udfs.load TO analyst;
+REVOKE ACCESS ON CONNECTION conn FOR SCRIPT udfs.load FROM analyst;
@@ -0,0 +1,27 @@
+package impersonationgrant
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+)
+
+func TestGrantGolden(t *testing.T) {
+	rec, tx := sqlrecorder.New(t)
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"impersonation_on": "end_users",
+			"grantee":          "service",
+		},
+	}
+	err := createData(d, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	err = deleteData(d, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	rec.AssertGolden(t, "grant")
+}
@@ -0,0 +1,183 @@
+package impersonationgrant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resource for allowing grantee to IMPERSONATE a User or the
+// members of a Role
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"impersonation_on": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "User or Role which may be impersonated. Compared case insensitively",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"grantee": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "User or Role which is allowed to impersonate. Compared case insensitively",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+	}
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := createData(d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func createData(d internal.Data, tx *sql.Tx) error {
+	on, grantee, err := arguments(d)
+	if err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf("GRANT IMPERSONATION ON %s TO %s", on, grantee)
+	_, err = tx.Exec(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(newID(on, grantee))
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	err := readData(ctx, d, locked.Tx)
+	return diag.FromErr(resource.RemoveIfNotFound(ctx, d, err))
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	on, grantee, err := arguments(d)
+	if err != nil {
+		return err
+	}
+
+	r, err := tx.QueryContext(ctx, "SELECT GRANTOR FROM SYS.EXA_DBA_IMPERSONATION_PRIVS WHERE UPPER(IMPERSONATION_ON) = UPPER(?) AND UPPER(GRANTEE) = UPPER(?)", on, grantee)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if !r.Next() {
+		return db.NewNotFoundError("IMPERSONATION GRANT", d.Id())
+	}
+	return nil
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := deleteData(d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func deleteData(d internal.Data, tx *sql.Tx) error {
+	on, grantee, err := arguments(d)
+	if err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf("REVOKE IMPERSONATION ON %s FROM %s", on, grantee)
+	_, err = tx.Exec(stmt)
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	err := importData(ctx, d, locked.Tx)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importData expects an id of form impersonation_on:grantee
+func importData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	id := d.Id()
+	if id == "" {
+		return errors.New("import expects id to be set")
+	}
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("import expects id of form impersonation_on:grantee: %s", id)
+	}
+	d.SetId(newID(parts[0], parts[1]))
+
+	err := d.Set("impersonation_on", strings.ToUpper(parts[0]))
+	if err != nil {
+		return err
+	}
+	err = d.Set("grantee", strings.ToUpper(parts[1]))
+	if err != nil {
+		return err
+	}
+	return readData(ctx, d, tx)
+}
+
+func arguments(d internal.Data) (on, grantee string, err error) {
+	on, _ = d.Get("impersonation_on").(string)
+	if on == "" {
+		err = fmt.Errorf("empty impersonation_on for %s", d)
+		return
+	}
+	grantee, _ = d.Get("grantee").(string)
+	if grantee == "" {
+		err = fmt.Errorf("empty grantee for %s", d)
+		return
+	}
+	return
+}
+
+func newID(on, grantee string) string {
+	return strings.ToUpper(on) + ":" + strings.ToUpper(grantee)
+}
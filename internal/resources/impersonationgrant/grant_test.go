@@ -0,0 +1,107 @@
+package impersonationgrant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestImpersonationGrant(t *testing.T) {
+	t.Parallel()
+	name := strings.ToUpper(fmt.Sprintf("%s_%s", t.Name(), nameSuffix))
+
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := exaprovider.TestLock(t, exaClient)
+		defer locked.Unlock()
+
+		stmts := []string{
+			fmt.Sprintf(`CREATE USER %s_SERVICE IDENTIFIED BY "secret"`, name),
+			fmt.Sprintf("CREATE ROLE %s_END_USERS", name),
+		}
+		for _, stmt := range stmts {
+			_, err := locked.Tx.Exec(stmt)
+			if err != nil {
+				if globallock.IsRollbackError(err) {
+					return err
+				}
+				t.Fatal("Unexpected error:", err)
+			}
+		}
+
+		create := &internal.TestData{
+			Values: map[string]interface{}{
+				"impersonation_on": name + "_END_USERS",
+				"grantee":          name + "_SERVICE",
+			},
+		}
+		err := createData(create, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+
+		imp := &internal.TestData{}
+		imp.SetId(strings.ToLower(create.Id()))
+		err = importData(context.TODO(), imp, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		if imp.Id() != create.Id() || imp.Get("grantee") != name+"_SERVICE" {
+			t.Fatalf("Unexpected import: %s %#v", imp.Id(), imp.Get("grantee"))
+		}
+
+		err = deleteData(create, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		err = readData(context.TODO(), imp, locked.Tx)
+		if !db.IsNotFound(err) {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Expected not found error:", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportedCaseIsKept(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "ANALYST:SUPPORT",
+		Attributes: map[string]string{
+			"id":               "ANALYST:SUPPORT",
+			"impersonation_on": "ANALYST",
+			"grantee":          "SUPPORT",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"impersonation_on": "analyst",
+		"grantee":          "Support",
+	})
+
+	diff, err := Resource().Diff(context.TODO(), state, config, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("Unexpected diff for different case: %#v", diff.Attributes)
+	}
+}
@@ -0,0 +1,2 @@
+GRANT IMPERSONATION ON end_users TO service;
+REVOKE IMPERSONATION ON end_users FROM service;
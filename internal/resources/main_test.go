@@ -5,8 +5,8 @@ import (
 	"os"
 	"testing"
 
-	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 )
 
@@ -21,7 +21,7 @@ func TestMain(m *testing.M) {
 }
 
 func testRun(m *testing.M) int {
-	exaClient = internal.MustCreateTestClient()
+	exaClient = testclient.MustCreateTestClient()
 
 	return m.Run()
 }
@@ -0,0 +1,347 @@
+package passwordpolicy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	parameter = "PASSWORD_SECURITY_POLICY"
+	off       = "OFF"
+	// maxPasswordLength is the longest password Exasol accepts
+	maxPasswordLength = 128
+)
+
+// rule of PASSWORD_SECURITY_POLICY. Unset rules are rendered as OFF.
+type rule struct {
+	attribute   string
+	max         int
+	description string
+}
+
+func (r rule) key() string {
+	return strings.ToUpper(r.attribute)
+}
+
+// rules in the order Exasol renders them
+var rules = []rule{
+	{"min_length", maxPasswordLength, "Minimal length of passwords"},
+	{"max_length", maxPasswordLength, "Maximal length of passwords"},
+	{"min_lower_case", maxPasswordLength, "Minimal number of lower case characters"},
+	{"min_upper_case", maxPasswordLength, "Minimal number of upper case characters"},
+	{"min_numeric_chars", maxPasswordLength, "Minimal number of numeric characters"},
+	{"min_special_chars", maxPasswordLength, "Minimal number of special characters"},
+	{"reusable_after_changes", 1000, "Number of password changes before a password may be reused"},
+	{"reusable_after_days", 36500, "Number of days before a password may be reused"},
+	{"max_failed_login_attempts", 1000, "Number of failed logins after which a User is locked"},
+}
+
+// minCharRules count characters which all have to fit into max_length
+var minCharRules = []string{"min_lower_case", "min_upper_case", "min_numeric_chars", "min_special_chars"}
+
+// Resource for the system wide password security policy. Leaving all
+// rules unset turns the policy OFF. Destroying restores the policy
+// found before the first apply.
+func Resource() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"policy": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Rendered value of PASSWORD_SECURITY_POLICY",
+		},
+		"original_policy": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Policy before the first apply. Restored on destroy",
+		},
+		"locked_users": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Users locked since they reached max_failed_login_attempts of the policy active in the database. Does not list Users violating other rules since Exasol only stores password hashes",
+		},
+	}
+	for _, r := range rules {
+		s[r.attribute] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  r.description + ". 0 or unset means OFF",
+			ValidateFunc: validation.IntBetween(0, r.max),
+		}
+	}
+	return &schema.Resource{
+		Schema:        s,
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return validate(policyValues(d))
+		},
+	}
+}
+
+type getter interface {
+	Get(name string) interface{}
+}
+
+func policyValues(d getter) map[string]int {
+	values := make(map[string]int, len(rules))
+	for _, r := range rules {
+		values[r.attribute], _ = d.Get(r.attribute).(int)
+	}
+	return values
+}
+
+// validate checks rules which depend on each other
+func validate(values map[string]int) error {
+	max := values["max_length"]
+	if max == 0 {
+		return nil
+	}
+	if values["min_length"] > max {
+		return fmt.Errorf("min_length %d exceeds max_length %d", values["min_length"], max)
+	}
+	sum := 0
+	for _, attr := range minCharRules {
+		sum += values[attr]
+	}
+	if sum > max {
+		return fmt.Errorf("minimal numbers of characters add up to %d which exceeds max_length %d", sum, max)
+	}
+	return nil
+}
+
+// renderPolicy renders values into the PASSWORD_SECURITY_POLICY format
+func renderPolicy(values map[string]int) string {
+	parts := make([]string, 0, len(rules))
+	enabled := false
+	for _, r := range rules {
+		v := off
+		if values[r.attribute] != 0 {
+			v = strconv.Itoa(values[r.attribute])
+			enabled = true
+		}
+		parts = append(parts, r.key()+"="+v)
+	}
+	if !enabled {
+		return off
+	}
+	return strings.Join(parts, ":")
+}
+
+// parsePolicy parses PASSWORD_SECURITY_POLICY. Unknown rules are ignored.
+func parsePolicy(policy string) (map[string]int, error) {
+	values := make(map[string]int, len(rules))
+	for _, r := range rules {
+		values[r.attribute] = 0
+	}
+	policy = strings.TrimSpace(policy)
+	if strings.EqualFold(policy, off) || policy == "" {
+		return values, nil
+	}
+	for _, part := range strings.Split(policy, ":") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid rule %q in %s", part, parameter)
+		}
+		attr := strings.ToLower(strings.TrimSpace(kv[0]))
+		if _, ok := values[attr]; !ok {
+			continue
+		}
+		v := strings.TrimSpace(kv[1])
+		if strings.EqualFold(v, off) {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of rule %s in %s: %w", kv[0], parameter, err)
+		}
+		values[attr] = n
+	}
+	return values, nil
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := createData(ctx, d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func createData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	original, err := db.ReadSystemParameter(ctx, tx, parameter)
+	if err != nil {
+		return err
+	}
+	err = d.Set("original_policy", original)
+	if err != nil {
+		return err
+	}
+	err = applyPolicy(d, tx)
+	if err != nil {
+		return err
+	}
+	d.SetId(parameter)
+	return readData(ctx, d, tx)
+}
+
+func applyPolicy(d internal.Data, tx *sql.Tx) error {
+	values := policyValues(d)
+	err := validate(values)
+	if err != nil {
+		return err
+	}
+	return db.SetSystemParameter(tx, parameter, renderPolicy(values))
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	return diag.FromErr(readData(ctx, d, locked.Tx))
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	policy, err := db.ReadSystemParameter(ctx, tx, parameter)
+	if err != nil {
+		return err
+	}
+	values, err := parsePolicy(policy)
+	if err != nil {
+		return err
+	}
+	err = d.Set("policy", policy)
+	if err != nil {
+		return err
+	}
+	for attr, v := range values {
+		err = d.Set(attr, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	locked, err := lockedUsers(ctx, tx, values["max_failed_login_attempts"])
+	if err != nil {
+		return err
+	}
+	return d.Set("locked_users", locked)
+}
+
+// lockedUsers lists Users which reached maxFailed login attempts
+func lockedUsers(ctx context.Context, tx *sql.Tx, maxFailed int) ([]interface{}, error) {
+	users := []interface{}{}
+	if maxFailed == 0 {
+		return users, nil
+	}
+	r, err := tx.QueryContext(ctx, "SELECT USER_NAME FROM SYS.EXA_DBA_USERS WHERE FAILED_LOGIN_ATTEMPTS >= ? ORDER BY USER_NAME", maxFailed)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	for r.Next() {
+		var name string
+		err = r.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, name)
+	}
+	return users, r.Err()
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := updateData(ctx, d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func updateData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	err := applyPolicy(d, tx)
+	if err != nil {
+		return err
+	}
+	return readData(ctx, d, tx)
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := deleteData(d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func deleteData(d internal.Data, tx *sql.Tx) error {
+	original, _ := d.Get("original_policy").(string)
+	if original == "" {
+		original = off
+	}
+	err := db.SetSystemParameter(tx, parameter, original)
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	err := importData(ctx, d, locked.Tx)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importData treats the current policy as original since the policy
+// before any change is unknown
+func importData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	if !strings.EqualFold(d.Id(), parameter) {
+		return fmt.Errorf("import expects id %s: %s", parameter, d.Id())
+	}
+	d.SetId(parameter)
+	err := readData(ctx, d, tx)
+	if err != nil {
+		return err
+	}
+	return d.Set("original_policy", d.Get("policy"))
+}
@@ -0,0 +1,189 @@
+package passwordpolicy
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+)
+
+func TestRenderPolicy(t *testing.T) {
+	values := map[string]int{
+		"min_length":                12,
+		"max_length":                64,
+		"min_lower_case":            1,
+		"reusable_after_days":       180,
+		"max_failed_login_attempts": 5,
+	}
+	policy := renderPolicy(values)
+	expected := "MIN_LENGTH=12:MAX_LENGTH=64:MIN_LOWER_CASE=1:MIN_UPPER_CASE=OFF:MIN_NUMERIC_CHARS=OFF:MIN_SPECIAL_CHARS=OFF:REUSABLE_AFTER_CHANGES=OFF:REUSABLE_AFTER_DAYS=180:MAX_FAILED_LOGIN_ATTEMPTS=5"
+	if policy != expected {
+		t.Fatalf("Expected %s but got %s", expected, policy)
+	}
+
+	parsed, err := parsePolicy(policy)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	for _, r := range rules {
+		if parsed[r.attribute] != values[r.attribute] {
+			t.Fatalf("Unexpected %s: %d", r.attribute, parsed[r.attribute])
+		}
+	}
+}
+
+func TestPolicyOff(t *testing.T) {
+	if renderPolicy(map[string]int{}) != "OFF" {
+		t.Fatal("Expected OFF for unset rules")
+	}
+	values, err := parsePolicy("OFF")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	for attr, v := range values {
+		if v != 0 {
+			t.Fatalf("Unexpected %s: %d", attr, v)
+		}
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	values, err := parsePolicy("MIN_LENGTH=8:FUTURE_RULE=3:MAX_FAILED_LOGIN_ATTEMPTS=OFF")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if values["min_length"] != 8 || values["max_failed_login_attempts"] != 0 {
+		t.Fatalf("Unexpected values: %v", values)
+	}
+
+	for _, policy := range []string{"MIN_LENGTH", "MIN_LENGTH=eight"} {
+		_, err = parsePolicy(policy)
+		if err == nil {
+			t.Fatalf("Expected error for %s", policy)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		values map[string]int
+		valid  bool
+	}{
+		"unbounded": {
+			values: map[string]int{"min_length": 200, "min_lower_case": 100},
+			valid:  true,
+		},
+		"min_exceeds_max": {
+			values: map[string]int{"min_length": 20, "max_length": 10},
+		},
+		"chars_exceed_max": {
+			values: map[string]int{"max_length": 10, "min_lower_case": 4, "min_upper_case": 4, "min_numeric_chars": 4},
+		},
+	}
+	for name, test := range tests {
+		err := validate(test.values)
+		if test.valid && err != nil {
+			t.Errorf("%s: Unexpected error: %s", name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: Expected error", name)
+		}
+	}
+}
+
+func TestPasswordPolicyGolden(t *testing.T) {
+	rec, tx := sqlrecorder.New(t)
+	rec.AddRows("SELECT SYSTEM_VALUE", []string{"SYSTEM_VALUE"}, []driver.Value{"MIN_LENGTH=8:MAX_FAILED_LOGIN_ATTEMPTS=3"})
+	rec.AddRows("SELECT USER_NAME", []string{"USER_NAME"}, []driver.Value{"ALICE"}, []driver.Value{"BOB"})
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"min_length":                8,
+			"max_failed_login_attempts": 3,
+		},
+	}
+	err := createData(context.TODO(), d, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if !reflect.DeepEqual(d.Get("locked_users"), []interface{}{"ALICE", "BOB"}) {
+		t.Fatalf("Unexpected locked users: %#v", d.Get("locked_users"))
+	}
+	err = deleteData(d, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	rec.AssertGolden(t, "lifecycle")
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := exaprovider.TestLock(t, exaClient)
+		defer locked.Unlock()
+
+		original, err := db.ReadSystemParameter(context.TODO(), locked.Tx, parameter)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+
+		d := &internal.TestData{
+			Values: map[string]interface{}{
+				"min_length":     12,
+				"min_upper_case": 2,
+			},
+		}
+		err = createData(context.TODO(), d, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+
+		imp := &internal.TestData{}
+		imp.SetId("password_security_policy")
+		err = importData(context.TODO(), imp, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		if imp.Get("min_length") != 12 || imp.Get("min_upper_case") != 2 || imp.Get("max_length") != 0 {
+			t.Fatalf("Unexpected import: %v", imp.Values)
+		}
+		if len(imp.Get("locked_users").([]interface{})) != 0 {
+			t.Fatalf("Unexpected locked users: %v", imp.Get("locked_users"))
+		}
+
+		err = deleteData(d, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		restored, err := db.ReadSystemParameter(context.TODO(), locked.Tx, parameter)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		if restored != original {
+			t.Fatalf("Expected restored policy %s but got %s", original, restored)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,5 @@
+SELECT SYSTEM_VALUE FROM SYS.EXA_PARAMETERS WHERE PARAMETER_NAME = UPPER(?); -- ["PASSWORD_SECURITY_POLICY"]
+ALTER SYSTEM SET PASSWORD_SECURITY_POLICY = 'MIN_LENGTH=8:MAX_LENGTH=OFF:MIN_LOWER_CASE=OFF:MIN_UPPER_CASE=OFF:MIN_NUMERIC_CHARS=OFF:MIN_SPECIAL_CHARS=OFF:REUSABLE_AFTER_CHANGES=OFF:REUSABLE_AFTER_DAYS=OFF:MAX_FAILED_LOGIN_ATTEMPTS=3';
+SELECT SYSTEM_VALUE FROM SYS.EXA_PARAMETERS WHERE PARAMETER_NAME = UPPER(?); -- ["PASSWORD_SECURITY_POLICY"]
+SELECT USER_NAME FROM SYS.EXA_DBA_USERS WHERE FAILED_LOGIN_ATTEMPTS >= ? ORDER BY USER_NAME; -- [3]
+ALTER SYSTEM SET PASSWORD_SECURITY_POLICY = 'MIN_LENGTH=8:MAX_FAILED_LOGIN_ATTEMPTS=3';
@@ -10,6 +10,7 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -24,6 +25,14 @@ func PhysicalSchema() *schema.Resource {
 				Description: "Name of Schema",
 			},
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    physicalSchemaV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resource.UpgradeGlobalIDV0,
+			},
+		},
 		CreateContext: createPhysicalSchema,
 		ReadContext:   readPhysicalSchema,
 		UpdateContext: updatePhysicalSchema,
@@ -109,7 +118,7 @@ func importPhysicalSchemaData(ctx context.Context, d internal.Data, tx *sql.Tx)
 	}
 
 	if !r.Next() {
-		return fmt.Errorf("schema %s not found", d.Id())
+		return db.NewNotFoundError("SCHEMA", d.Id())
 	}
 	d.SetId(strings.ToUpper(d.Id()))
 	return nil
@@ -134,7 +143,7 @@ func readPhysicalSchemaTx(ctx context.Context, d internal.Data, tx *sql.Tx) diag
 	}
 
 	if !res.Next() {
-		return diag.Errorf("Schema %s not found", name)
+		return diag.FromErr(resource.RemoveIfNotFound(ctx, d, db.NewNotFoundError("SCHEMA", name)))
 	}
 
 	d.SetId(strings.ToUpper(name))
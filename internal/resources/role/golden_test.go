@@ -0,0 +1,20 @@
+package role
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+)
+
+func TestCreateDataGolden(t *testing.T) {
+	rec, tx := sqlrecorder.New(t)
+	err := createData(&internal.TestData{Values: map[string]interface{}{
+		"name":    "foo",
+		"comment": "bar",
+	}}, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	rec.AssertGolden(t, "create")
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -23,6 +25,24 @@ func Resource() *schema.Resource {
 				Required:    true,
 				Description: "Name of Role",
 			},
+			"comment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comment for the Role",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp the Role was created at",
+			},
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resource.UpgradeGlobalIDV0,
+			},
 		},
 		CreateContext: createRole,
 		UpdateContext: updateRole,
@@ -57,6 +77,14 @@ func createData(d internal.Data, tx *sql.Tx) error {
 	if err != nil {
 		return err
 	}
+
+	comment, _ := d.Get("comment").(string)
+	if comment != "" {
+		err = db.CommentGlobal(tx, "ROLE", name, comment)
+		if err != nil {
+			return err
+		}
+	}
 	d.SetId(strings.ToUpper(name))
 	return err
 }
@@ -123,16 +151,12 @@ func readRole(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	diags, _ := readData(ctx, d, locked.Tx)
-	return diags
+	_, err := readData(ctx, d, locked.Tx)
+	return diag.FromErr(resource.RemoveIfNotFound(ctx, d, err))
 }
 
 func readData(ctx context.Context, d internal.Data, tx *sql.Tx) (diag.Diagnostics, error) {
-	name, err := argument.Name(d)
-	if err != nil {
-		return diag.FromErr(err), err
-	}
-	_, err = tx.ExecContext(ctx, "SELECT ROLE_NAME FROM SYS.EXA_ALL_ROLES WHERE ROLE_NAME = ?", name)
+	err := computed.ReadRole(ctx, d, tx)
 	return diag.FromErr(err), err
 }
 
@@ -159,6 +183,17 @@ func updateData(ctx context.Context, d internal.Data, tx *sql.Tx) diag.Diagnosti
 		}
 	}
 
+	if d.HasChange("comment") {
+		name, err := argument.Name(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = db.CommentGlobal(tx, "ROLE", name, d.Get("comment").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	diags, _ := readData(ctx, d, tx)
 	return diags
 }
@@ -0,0 +1,2 @@
+CREATE ROLE foo;
+COMMENT ON ROLE foo IS 'bar';
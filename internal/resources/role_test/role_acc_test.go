@@ -27,8 +27,8 @@ func TestAccExasolRole_rename(t *testing.T) {
 
 	ps := test.NewDefaultAccProviders()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`%s
@@ -76,7 +76,7 @@ func TestAccExasolRole_import(t *testing.T) {
 	ps := test.NewDefaultAccProviders()
 
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: ps.Factories,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: tryDeleteRole,
@@ -100,6 +100,81 @@ func TestAccExasolRole_import(t *testing.T) {
 	})
 }
 
+func TestAccExasolRole_comment(t *testing.T) {
+
+	dbName := fmt.Sprintf("%s_%s", t.Name(), roleSuffix)
+
+	ps := test.NewDefaultAccProviders()
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: ps.Factories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`%s
+				resource "exasol_role" "test_role" {
+					name    = "%s"
+					comment = "First comment"
+				}
+				`, test.HCLProviderFromConf(exaConf), dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("exasol_role.test_role", "comment", "First comment"),
+					resource.TestCheckResourceAttrSet("exasol_role.test_role", "created"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+				resource "exasol_role" "test_role" {
+					name    = "%s"
+					comment = "Second's comment"
+				}
+				`, test.HCLProviderFromConf(exaConf), dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("exasol_role.test_role", "comment", "Second's comment"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExasolRole_disappears(t *testing.T) {
+
+	dbName := fmt.Sprintf("%s_%s", t.Name(), roleSuffix)
+
+	ps := test.NewDefaultAccProviders()
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: ps.Factories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`%s
+				resource "exasol_role" "test_role" {
+					name = "%s"
+				}
+				`, test.HCLProviderFromConf(exaConf), dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testExist(ps.Exasol, "exasol_role.test_role"),
+					testDrop(ps.Exasol, dbName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testDrop(p *schema.Provider, name string) resource.TestCheckFunc {
+
+	return func(state *terraform.State) error {
+
+		c := p.Meta().(*exaprovider.Client)
+		locked := c.Lock(context.TODO())
+		defer locked.Unlock()
+
+		_, err := locked.Tx.Exec(fmt.Sprintf("DROP ROLE %s", name))
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	}
+}
+
 // exists checks whether the Role exists
 func exists(ctx context.Context, tx *sql.Tx, name string) (bool, error) {
 	r, err := tx.QueryContext(ctx, "SELECT ROLE_NAME FROM SYS.EXA_ALL_ROLES WHERE UPPER(ROLE_NAME) = UPPER(?)", name)
@@ -0,0 +1,265 @@
+package sqlscript
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/internal/logging"
+	"github.com/abergmeier/terraform-provider-exasol/internal/statements"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	maxReadRows = 1000
+)
+
+// Resource for arbitrary SQL scripts not covered by other Resources.
+// A changed result of read_query plans a replacement.
+// Statements are traced without their text since they may contain
+// secrets.
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"create_sql": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Statements separated by ; to run on create. Statements creating scripts or functions end with a line only containing /",
+			},
+			"destroy_sql": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Statements to run on destroy. Separated like create_sql",
+			},
+			"update_sql": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Statements to run when create_sql or update_sql change. Separated like create_sql. Without it changes to create_sql recreate the Resource.",
+			},
+			"read_query": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "SELECT whose result is compared on refresh. A different result recreates the Resource.",
+				ValidateFunc: validateSelect,
+			},
+			"read_result_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hash of the result of read_query when last applied",
+			},
+			"current_result_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hash of the result of read_query on last refresh",
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		CustomizeDiff: customizeDiff,
+	}
+}
+
+func validateSelect(i interface{}, k string) ([]string, []error) {
+	q, _ := i.(string)
+	s := &statements.Select{
+		Query: q,
+	}
+	err := s.Validate()
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+func customizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	applied, _ := d.Get("read_result_hash").(string)
+	current, _ := d.Get("current_result_hash").(string)
+	if applied != "" && current != "" && applied != current {
+		// Result of read_query drifted so replay create_sql
+		err := d.SetNewComputed("read_result_hash")
+		if err != nil {
+			return err
+		}
+		return d.ForceNew("read_result_hash")
+	}
+	if !d.HasChange("create_sql") {
+		return nil
+	}
+	if d.Get("update_sql").(string) != "" {
+		return nil
+	}
+	return d.ForceNew("create_sql")
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ctx = exaprovider.WithRedactedStatements(ctx)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := createData(ctx, d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func createData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	createSQL := d.Get("create_sql").(string)
+	s := &statements.Script{
+		SQL: createSQL,
+	}
+	err := s.Execute(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = setReadResultHash(ctx, d, tx)
+	if err != nil {
+		return err
+	}
+
+	// The id has to stay stable when create_sql is updated in place
+	d.SetId(resource.UniqueId())
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ctx = exaprovider.WithRedactedStatements(ctx)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	return diag.FromErr(readData(ctx, d, locked.Tx))
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	expected, _ := d.Get("read_result_hash").(string)
+	actual, err := readResultHash(ctx, d, tx)
+	if err != nil {
+		return err
+	}
+
+	if expected != "" && actual != expected {
+		logging.Warn(ctx, "Result of read_query changed, planning replacement", map[string]interface{}{
+			"id": d.Id(),
+		})
+	}
+	return d.Set("current_result_hash", actual)
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ctx = exaprovider.WithRedactedStatements(ctx)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := updateData(ctx, d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func updateData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	if d.HasChange("create_sql") || d.HasChange("update_sql") {
+		s := &statements.Script{
+			SQL: d.Get("update_sql").(string),
+		}
+		err := s.Execute(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return setReadResultHash(ctx, d, tx)
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ctx = exaprovider.WithRedactedStatements(ctx)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := deleteData(ctx, d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func deleteData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	s := &statements.Script{
+		SQL: d.Get("destroy_sql").(string),
+	}
+	err := s.Execute(ctx, tx)
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func setReadResultHash(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	hash, err := readResultHash(ctx, d, tx)
+	if err != nil {
+		return err
+	}
+	err = d.Set("read_result_hash", hash)
+	if err != nil {
+		return err
+	}
+	return d.Set("current_result_hash", hash)
+}
+
+// readResultHash hashes the result of read_query.
+// Returns empty string without read_query.
+func readResultHash(ctx context.Context, d internal.Data, tx *sql.Tx) (string, error) {
+	q, _ := d.Get("read_query").(string)
+	if q == "" {
+		return "", nil
+	}
+
+	s := &statements.Select{
+		Query:   q,
+		MaxRows: maxReadRows,
+	}
+	columns, rows, err := s.Execute(ctx, tx)
+	if err != nil {
+		return "", err
+	}
+
+	elems := []string{strings.Join(columns, "\x1f")}
+	for _, row := range rows {
+		values := make([]string, 0, len(columns))
+		for _, c := range columns {
+			v, ok := row[c]
+			if !ok {
+				v = "\x00"
+			}
+			values = append(values, v)
+		}
+		elems = append(elems, "\x1e"+strings.Join(values, "\x1f"))
+	}
+	hash, err := internal.HashStrings(elems...)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash), nil
+}
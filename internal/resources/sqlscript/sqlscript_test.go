@@ -0,0 +1,127 @@
+package sqlscript
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCreateAndDelete(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"create_sql":  fmt.Sprintf("CREATE SCHEMA %s; COMMENT ON SCHEMA %s IS 'a;b'", name, name),
+			"destroy_sql": fmt.Sprintf("DROP SCHEMA %s", name),
+			"read_query":  fmt.Sprintf("SELECT SCHEMA_COMMENT FROM SYS.EXA_SCHEMAS WHERE SCHEMA_NAME = UPPER('%s')", name),
+		},
+	}
+	err := createData(context.TODO(), d, locked.Tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Id() == "" {
+		t.Fatal("Expected id to be set")
+	}
+	hash := d.Get("read_result_hash").(string)
+	if hash == "" {
+		t.Fatal("Expected read_result_hash to be set")
+	}
+
+	err = readData(context.TODO(), d, locked.Tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Id() == "" {
+		t.Fatal("Unexpected drift")
+	}
+
+	_, err = locked.Tx.Exec(fmt.Sprintf("COMMENT ON SCHEMA %s IS 'changed'", name))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	err = readData(context.TODO(), d, locked.Tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Id() == "" {
+		t.Fatal("Expected drift to keep id for replacement")
+	}
+	if d.Get("current_result_hash") == hash || d.Get("read_result_hash") != hash {
+		t.Fatalf("Expected drift in current_result_hash: %#v", d.Values)
+	}
+
+	err = deleteData(context.TODO(), d, locked.Tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+}
+
+func TestCreateUniqueId(t *testing.T) {
+	t.Parallel()
+
+	locked := exaClient.Lock(context.TODO())
+	defer locked.Unlock()
+
+	ids := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		d := &internal.TestData{
+			Values: map[string]interface{}{
+				"create_sql": "SELECT 1 FROM DUAL",
+			},
+		}
+		err := createData(context.TODO(), d, locked.Tx)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if ids[d.Id()] {
+			t.Fatalf("Expected unique id: %s", d.Id())
+		}
+		ids[d.Id()] = true
+	}
+}
+
+func TestCustomizeDiffDrift(t *testing.T) {
+	state := func(current string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "abc",
+			Attributes: map[string]string{
+				"id":                  "abc",
+				"create_sql":          "CREATE SCHEMA A",
+				"destroy_sql":         "DROP SCHEMA A",
+				"read_query":          "SELECT 1",
+				"read_result_hash":    "1234",
+				"current_result_hash": current,
+			},
+		}
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"create_sql":  "CREATE SCHEMA A",
+		"destroy_sql": "DROP SCHEMA A",
+		"read_query":  "SELECT 1",
+	})
+
+	diff, err := Resource().Diff(context.TODO(), state("1234"), config, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if diff != nil && diff.RequiresNew() {
+		t.Fatalf("Unexpected replacement: %#v", diff.Attributes)
+	}
+
+	diff, err = Resource().Diff(context.TODO(), state("5678"), config, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("Expected drift to replace Resource: %#v", diff)
+	}
+}
@@ -0,0 +1,217 @@
+package systemparameter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// passwordPolicy is also managed by exasol_password_policy
+	passwordPolicy = "PASSWORD_SECURITY_POLICY"
+)
+
+// Resource for a system wide parameter set via ALTER SYSTEM.
+// Destroying it restores the value found before the first apply.
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of parameter (e.g. QUERY_TIMEOUT). Case insensitive and stored upper case. Do not combine PASSWORD_SECURITY_POLICY with exasol_password_policy since both manage the same parameter",
+				ValidateFunc: validateName,
+				StateFunc:    normalizeName,
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "System value of parameter",
+			},
+			"original_value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Value before the first apply. Restored on destroy",
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+	}
+}
+
+func validateName(i interface{}, k string) ([]string, []error) {
+	warnings, errs := validation.StringMatch(regexp.MustCompile(`^(?i)[A-Z_][A-Z0-9_]*$`), "expected parameter name")(i, k)
+	if len(errs) != 0 {
+		return warnings, errs
+	}
+	if normalizeName(i) == passwordPolicy {
+		warnings = append(warnings, fmt.Sprintf("%s: %s conflicts with exasol_password_policy if both are used", k, passwordPolicy))
+	}
+	return warnings, nil
+}
+
+func normalizeName(i interface{}) string {
+	name, _ := i.(string)
+	return strings.ToUpper(name)
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := createData(ctx, d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func createData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+	name = normalizeName(name)
+
+	original, err := db.ReadSystemParameter(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("original_value", original)
+	if err != nil {
+		return err
+	}
+
+	err = db.SetSystemParameter(tx, name, d.Get("value").(string))
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	err := readData(ctx, d, locked.Tx)
+	return diag.FromErr(resource.RemoveIfNotFound(ctx, d, err))
+}
+
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+	value, err := db.ReadSystemParameter(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+	return d.Set("value", value)
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := updateData(d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func updateData(d internal.Data, tx *sql.Tx) error {
+	if !d.HasChange("value") {
+		return nil
+	}
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+	return db.SetSystemParameter(tx, name, d.Get("value").(string))
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := c.Lock(ctx)
+		defer locked.Unlock()
+		err := deleteData(d, locked.Tx)
+		if err != nil {
+			return err
+		}
+		return locked.Tx.Commit()
+	})
+	return diag.FromErr(err)
+}
+
+func deleteData(d internal.Data, tx *sql.Tx) error {
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+	original, _ := d.Get("original_value").(string)
+	err = db.SetSystemParameter(tx, name, original)
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked := c.Lock(ctx)
+	defer locked.Unlock()
+	err := importData(ctx, d, locked.Tx)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importData treats the current value as original since the value
+// before any change is unknown
+func importData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+	name := d.Id()
+	if name == "" {
+		return errors.New("import expects id to be set")
+	}
+	name = normalizeName(name)
+	d.SetId(name)
+	err := d.Set("name", name)
+	if err != nil {
+		return err
+	}
+	err = readData(ctx, d, tx)
+	if err != nil {
+		return err
+	}
+	return d.Set("original_value", d.Get("value"))
+}
@@ -0,0 +1,132 @@
+package systemparameter
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+)
+
+func TestSystemParameterGolden(t *testing.T) {
+	rec, tx := sqlrecorder.New(t)
+	rec.AddRows("SELECT SYSTEM_VALUE", []string{"SYSTEM_VALUE"}, []driver.Value{"0"})
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":  "query_timeout",
+			"value": "300",
+		},
+	}
+	err := createData(context.TODO(), d, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	d.NewValues = map[string]interface{}{
+		"name":           "query_timeout",
+		"value":          "600",
+		"original_value": "0",
+	}
+	err = updateData(d, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	err = deleteData(d, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	rec.AssertGolden(t, "lifecycle")
+}
+
+func TestValidateName(t *testing.T) {
+	tests := map[string]bool{
+		"QUERY_TIMEOUT":            true,
+		"query_timeout":            true,
+		"QUERY TIMEOUT":            false,
+		"1QUERY":                   false,
+		"PASSWORD_SECURITY_POLICY": true,
+		"password_security_policy": true,
+	}
+
+	for name, valid := range tests {
+		_, errs := validateName(name, "name")
+		if valid != (len(errs) == 0) {
+			t.Errorf("Unexpected validation of %s: %v", name, errs)
+		}
+	}
+
+	warnings, _ := validateName("password_security_policy", "name")
+	if len(warnings) != 1 {
+		t.Errorf("Expected warning about exasol_password_policy: %v", warnings)
+	}
+}
+
+func TestSystemParameter(t *testing.T) {
+	err := globallock.RunAndRetryRollbacks(func() error {
+		locked := exaprovider.TestLock(t, exaClient)
+		defer locked.Unlock()
+
+		original, err := db.ReadSystemParameter(context.TODO(), locked.Tx, "NLS_DATE_FORMAT")
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+
+		d := &internal.TestData{
+			Values: map[string]interface{}{
+				"name":  "NLS_DATE_FORMAT",
+				"value": "DD.MM.YYYY",
+			},
+		}
+		err = createData(context.TODO(), d, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		if d.Get("original_value") != original {
+			t.Fatalf("Expected original value %s but got %#v", original, d.Get("original_value"))
+		}
+
+		imp := &internal.TestData{}
+		imp.SetId("nls_date_format")
+		err = importData(context.TODO(), imp, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		if imp.Get("value") != "DD.MM.YYYY" || imp.Id() != "NLS_DATE_FORMAT" {
+			t.Fatalf("Unexpected import: %s %#v", imp.Id(), imp.Get("value"))
+		}
+
+		err = deleteData(d, locked.Tx)
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		restored, err := db.ReadSystemParameter(context.TODO(), locked.Tx, "NLS_DATE_FORMAT")
+		if err != nil {
+			if globallock.IsRollbackError(err) {
+				return err
+			}
+			t.Fatal("Unexpected error:", err)
+		}
+		if restored != original {
+			t.Fatalf("Expected restored value %s but got %s", original, restored)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,4 @@
+SELECT SYSTEM_VALUE FROM SYS.EXA_PARAMETERS WHERE PARAMETER_NAME = UPPER(?); -- ["QUERY_TIMEOUT"]
+ALTER SYSTEM SET QUERY_TIMEOUT = 300;
+ALTER SYSTEM SET QUERY_TIMEOUT = 600;
+ALTER SYSTEM SET QUERY_TIMEOUT = 0;
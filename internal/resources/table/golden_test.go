@@ -0,0 +1,47 @@
+package table
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+)
+
+func TestCreateDataMutateGolden(t *testing.T) {
+	tests := map[string]struct {
+		values   map[string]interface{}
+		replace  bool
+		comp     string
+		like     string
+		subquery string
+	}{
+		"create_composite": {
+			values: map[string]interface{}{},
+			comp:   "a VARCHAR(20),\nb DECIMAL(18,0),\n",
+		},
+		"create_like": {
+			values: map[string]interface{}{
+				"comment": "copy",
+			},
+			like: "S.OTHER",
+		},
+		"replace_subquery": {
+			values:   map[string]interface{}{},
+			replace:  true,
+			subquery: "SELECT * FROM S.OTHER",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			rec, tx := sqlrecorder.New(t)
+			err := createDataMutate(context.Background(), &internal.TestData{Values: test.values}, tx, "S", "T", test.comp, test.like, test.subquery, test.replace)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			rec.AssertGolden(t, name)
+		})
+	}
+}
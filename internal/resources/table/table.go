@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"os"
 	"reflect"
 	"strings"
 
@@ -32,8 +31,9 @@ func Resource() *schema.Resource {
 			},
 			"schema": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Schema to create Table in",
+				Optional:    true,
+				Computed:    true,
+				Description: "Schema to create Table in. Defaults to default_schema of the provider.",
 				ForceNew:    true,
 			},
 			"composite": {
@@ -69,11 +69,21 @@ func Resource() *schema.Resource {
 			"columns":             computed.ColumnsSchema(),
 			"primary_key_indices": computed.PrimaryKeysSchema(),
 			"foreign_key_indices": computed.ForeignKeysSchema(),
+			"object_name":         argument.ObjectNameSchema(),
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeV0,
+			},
 		},
 		CustomizeDiff: customdiff.All(
 			customdiff.ForceNewIf("composite", isReplaceFalse),
 			customdiff.ForceNewIf("subquery", isReplaceFalse),
 			customdiff.ForceNewIf("like", isReplaceFalse),
+			argument.CustomizeDefaults(exaprovider.ArgumentDefaults),
 		),
 		CreateContext: create,
 		ReadContext:   read,
@@ -93,7 +103,7 @@ func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ra, diags := argument.ExtractRequiredArguments(d)
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, c.Defaults)
 	if diags.HasError() {
 		return diags
 	}
@@ -120,7 +130,7 @@ func createData(ctx context.Context, d internal.Data, tx *sql.Tx, args argument.
 		return fmt.Errorf("Only one of composite, like or subquery may be used %#v", like)
 	}
 
-	err := createDataMutate(d, tx, args.Schema, args.Name, comp, like, subquery, replace)
+	err := createDataMutate(ctx, d, tx, args.Schema, args.Name, comp, like, subquery, replace)
 	if err != nil {
 		return err
 	}
@@ -155,12 +165,22 @@ func postCreate(ctx context.Context, d internal.Data, tx *sql.Tx, schema, name s
 		return err
 	}
 
+	err = d.Set("schema", schema)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("object_name", name)
+	if err != nil {
+		return err
+	}
+
 	d.SetId(resource.NewID(schema, name))
 	return nil
 }
 
 // createDataMutate contains the mutating part of creating a Table
-func createDataMutate(d internal.Data, tx *sql.Tx, schema, name string, comp, like, subquery interface{}, replace bool) error {
+func createDataMutate(ctx context.Context, d internal.Data, tx *sql.Tx, schema, name string, comp, like, subquery interface{}, replace bool) error {
 
 	initWords := "CREATE TABLE"
 	if replace {
@@ -177,15 +197,12 @@ func createDataMutate(d internal.Data, tx *sql.Tx, schema, name string, comp, li
 	if !reflect.ValueOf(comp).IsZero() {
 		cleaned := strings.Trim(comp.(string), ",\n ")
 		stmt := fmt.Sprintf("%s %s.%s (%s)%s", initWords, schema, name, cleaned, commentSuffix)
-		setStmtHash("composite", stmt, d)
 		_, err = tx.Exec(stmt)
 	} else if !reflect.ValueOf(like).IsZero() {
 		stmt := fmt.Sprintf("%s %s.%s LIKE %s%s", initWords, schema, name, like.(string), commentSuffix)
-		setStmtHash("like", stmt, d)
 		_, err = tx.Exec(stmt)
 	} else if !reflect.ValueOf(subquery).IsZero() {
 		stmt := fmt.Sprintf("%s %s.%s AS %s%s", initWords, schema, name, subquery.(string), commentSuffix)
-		setStmtHash("subquery", stmt, d)
 		_, err = tx.Exec(stmt)
 	} else {
 		panic("Internal conditions wrong")
@@ -198,7 +215,7 @@ func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ra, diags := argument.ExtractRequiredArguments(d)
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, c.Defaults)
 	if diags.HasError() {
 		return diags
 	}
@@ -226,7 +243,7 @@ func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*sche
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	err := importData(ctx, d, locked.Tx)
+	err := importData(ctx, d, locked.Tx, c.Defaults)
 	if err != nil {
 		return nil, err
 	}
@@ -237,10 +254,15 @@ func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*sche
 	return []*schema.ResourceData{d}, nil
 }
 
-func importData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+func importData(ctx context.Context, d internal.Data, tx *sql.Tx, defaults argument.Defaults) error {
 	id := d.Id()
 
-	m, err := resource.GetMetaFromQNDefault(id, d.Get("schema").(string))
+	schemaDefault, _ := d.Get("schema").(string)
+	if schemaDefault == "" {
+		schemaDefault = defaults.Schema
+	}
+
+	m, err := resource.GetMetaFromQNDefault(id, schemaDefault)
 	if err != nil {
 		return err
 	}
@@ -249,7 +271,7 @@ func importData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
 		return errors.New("Missing schema in import")
 	}
 
-	err = d.Set("name", m.ObjectName)
+	err = d.Set("name", resource.TrimPrefix(m.ObjectName, defaults.ObjectPrefix))
 	if err != nil {
 		return err
 	}
@@ -295,7 +317,7 @@ func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Di
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ra, diags := argument.ExtractRequiredArguments(d)
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, c.Defaults)
 	if diags.HasError() {
 		return diags
 	}
@@ -306,7 +328,7 @@ func readData(ctx context.Context, d internal.Data, tx *sql.Tx, args argument.Re
 
 	tr, err := computed.ReadTable(ctx, tx, args.Schema, args.Name)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(resource.RemoveIfNotFound(ctx, d, err))
 	}
 
 	err = tr.SetComment(d)
@@ -354,6 +376,11 @@ func readData(ctx context.Context, d internal.Data, tx *sql.Tx, args argument.Re
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("object_name", args.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(resource.NewID(args.Schema, args.Name))
 	return nil
 }
@@ -362,7 +389,7 @@ func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ra, diags := argument.ExtractRequiredArguments(d)
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, c.Defaults)
 	if diags.HasError() {
 		return diags
 	}
@@ -377,9 +404,13 @@ func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 func updateData(ctx context.Context, d internal.Data, tx *sql.Tx, args argument.RequiredArguments) diag.Diagnostics {
 
 	if d.HasChange("name") {
-		old, new := d.GetChange("name")
+		old, _ := d.GetChange("name")
+		oldName := args.Prefix + old.(string)
+		if recorded, _ := d.GetChange("object_name"); recorded != nil && recorded.(string) != "" {
+			oldName = recorded.(string)
+		}
 
-		err := db.Rename(tx, "TABLE", old.(string), new.(string), args.Schema)
+		err := db.Rename(tx, "TABLE", oldName, args.Name, args.Schema)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -387,15 +418,12 @@ func updateData(ctx context.Context, d internal.Data, tx *sql.Tx, args argument.
 
 	replaceNecessary := d.HasChange("composite") || d.HasChange("subquery") || d.HasChange("like")
 	if replaceNecessary {
-		err := createData(ctx, d, tx, argument.RequiredArguments{
-			Schema: args.Schema,
-			Name:   d.Get("name").(string),
-		}, true)
+		err := createData(ctx, d, tx, args, true)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} else if d.HasChange("comment") {
-		err := db.Comment(tx, "TABLE", d.Get("name").(string), d.Get("comment").(string), args.Schema)
+		err := db.Comment(tx, "TABLE", args.Name, d.Get("comment").(string), args.Schema)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -414,13 +442,3 @@ func countEmpty(elems ...interface{}) int {
 	}
 	return i
 }
-
-func setStmtHash(variant, stmt string, d internal.Data) {
-	stmtHash, err := internal.HashStrings(variant, stmt)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		// ignore error
-	}
-
-	d.Set("hash_stmt", string(stmtHash))
-}
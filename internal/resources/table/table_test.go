@@ -124,7 +124,7 @@ func TestImport(t *testing.T) {
 	}
 	imp.SetId(resource.NewID(schemaName, name))
 
-	err := importData(context.TODO(), imp, locked.Tx)
+	err := importData(context.TODO(), imp, locked.Tx, argument.Defaults{})
 	if err != nil {
 		t.Fatal("Unexpected error:", err)
 	}
@@ -138,7 +138,7 @@ func TestImport(t *testing.T) {
 	}
 	imp.SetId(name)
 
-	err = importData(context.TODO(), imp, locked.Tx)
+	err = importData(context.TODO(), imp, locked.Tx, argument.Defaults{})
 	if err != nil {
 		t.Fatal("Unexpected error:", err)
 	}
@@ -228,7 +228,7 @@ func TestImportConstraint(t *testing.T) {
 	}
 	imp.SetId(resource.NewID(schemaName, name))
 
-	err := importData(context.TODO(), imp, locked.Tx)
+	err := importData(context.TODO(), imp, locked.Tx, argument.Defaults{})
 	if err != nil {
 		t.Fatal("Unexpected error:", err)
 	}
@@ -243,3 +243,32 @@ DISTRIBUTE BY A,
 		t.Fatalf("Unexpected composite:\n%s", diff.LineDiff(composite, expectedComposite))
 	}
 }
+
+func TestReadGone(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+
+	locked := exaprovider.TestLock(t, exaClient)
+	defer locked.Unlock()
+
+	locked.Tx.Exec(fmt.Sprintf("DROP TABLE %s.%s", schemaName, name))
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"composite": "A VARCHAR(20),",
+		},
+	}
+	read.SetId(resource.NewID(schemaName, name))
+
+	diags := readData(context.TODO(), read, locked.Tx, argument.RequiredArguments{
+		Schema: schemaName,
+		Name:   name,
+	})
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+	if read.Id() != "" {
+		t.Fatalf("Expected Table to be removed from state: %s", read.Id())
+	}
+}
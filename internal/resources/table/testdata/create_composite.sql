@@ -0,0 +1,2 @@
+CREATE TABLE S.T (a VARCHAR(20),
+b DECIMAL(18,0));
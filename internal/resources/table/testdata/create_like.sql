@@ -0,0 +1 @@
+CREATE TABLE S.T LIKE S.OTHER COMMENT IS 'copy';
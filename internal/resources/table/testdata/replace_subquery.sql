@@ -0,0 +1 @@
+CREATE OR REPLACE TABLE S.T AS SELECT * FROM S.OTHER;
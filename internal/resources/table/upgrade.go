@@ -0,0 +1,64 @@
+package table
+
+import (
+	"context"
+
+	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceV0 is the Table schema before versioning was introduced
+func resourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"schema": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"composite": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"subquery": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"like": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"replace": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"column_indices":      computed.ColumnIndicesSchema(),
+			"columns":             computed.ColumnsSchema(),
+			"primary_key_indices": computed.PrimaryKeysSchema(),
+			"foreign_key_indices": computed.ForeignKeysSchema(),
+		},
+	}
+}
+
+// upgradeV0 normalizes the id and drops hash_stmt, which older
+// versions tried to write although it never was part of the schema
+func upgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	rawState, err := resource.UpgradeSchemaObjectIDV0(ctx, rawState, meta)
+	if err != nil || rawState == nil {
+		return rawState, err
+	}
+
+	resource.DropAttributes(rawState, "hash_stmt")
+	if _, ok := rawState["replace"]; !ok {
+		rawState["replace"] = false
+	}
+	return rawState, nil
+}
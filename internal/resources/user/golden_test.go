@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+)
+
+func TestCreateDataGolden(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"create_password": {
+			"name":           "foo",
+			"password":       "secret",
+			"kerberos":       "",
+			"ldap":           "",
+			"openid_subject": "",
+		},
+		"create_kerberos": {
+			"name":           "foo",
+			"password":       "",
+			"kerberos":       "foo@EXAMPLE.COM",
+			"ldap":           "",
+			"openid_subject": "",
+		},
+		"create_ldap": {
+			"name":           "foo",
+			"password":       "",
+			"kerberos":       "",
+			"ldap":           "cn=foo,dc=example,dc=com",
+			"openid_subject": "",
+		},
+		"create_password_escaped": {
+			"name":           "foo",
+			"password":       `se"cret`,
+			"kerberos":       "",
+			"ldap":           "",
+			"openid_subject": "",
+		},
+		"create_ldap_escaped": {
+			"name":           "foo",
+			"password":       "",
+			"kerberos":       "",
+			"ldap":           "cn=o'brien,dc=example,dc=com",
+			"openid_subject": "",
+		},
+		"create_openid": {
+			"name":           "foo",
+			"password":       "",
+			"kerberos":       "",
+			"ldap":           "",
+			"openid_subject": "foo@example.com",
+		},
+	}
+
+	for name, values := range tests {
+		values := values
+		t.Run(name, func(t *testing.T) {
+			rec, tx := sqlrecorder.New(t)
+			err := createData(&internal.TestData{Values: values}, tx)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			rec.AssertGolden(t, name)
+		})
+	}
+}
+
+func TestDeleteDataGolden(t *testing.T) {
+	rec, tx := sqlrecorder.New(t)
+	err := deleteData(&internal.TestData{Values: map[string]interface{}{"name": "foo"}}, tx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	rec.AssertGolden(t, "delete")
+}
+
+func TestUpdateDataGolden(t *testing.T) {
+	rec, tx := sqlrecorder.New(t)
+	rec.AddRows("SELECT DISTINGUISHED_NAME", []string{"DISTINGUISHED_NAME", "KERBEROS_PRINCIPAL", "OPENID_SUBJECT"}, []driver.Value{"cn=foo,dc=example,dc=com", nil, nil})
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":           "foo",
+			"password":       "secret",
+			"kerberos":       "",
+			"ldap":           "",
+			"openid_subject": "",
+		},
+		NewValues: map[string]interface{}{
+			"name":           "foo",
+			"password":       "",
+			"kerberos":       "",
+			"ldap":           "cn=foo,dc=example,dc=com",
+			"openid_subject": "",
+		},
+	}
+	err := updateData(context.TODO(), d, tx, db.Version{Major: 7, Minor: 1})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	rec.AssertGolden(t, "update_ldap")
+}
@@ -0,0 +1 @@
+CREATE USER foo IDENTIFIED BY KERBEROS PRINCIPAL 'foo@EXAMPLE.COM';
@@ -0,0 +1 @@
+CREATE USER foo IDENTIFIED AT LDAP AS 'cn=foo,dc=example,dc=com';
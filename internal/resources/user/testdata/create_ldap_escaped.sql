@@ -0,0 +1 @@
+CREATE USER foo IDENTIFIED AT LDAP AS 'cn=o''brien,dc=example,dc=com';
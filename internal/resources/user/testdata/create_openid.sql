@@ -0,0 +1 @@
+CREATE USER foo IDENTIFIED BY OPENID SUBJECT 'foo@example.com';
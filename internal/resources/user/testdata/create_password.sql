@@ -0,0 +1 @@
+CREATE USER foo IDENTIFIED BY "secret";
@@ -0,0 +1 @@
+DROP USER foo;
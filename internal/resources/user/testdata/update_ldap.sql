@@ -0,0 +1,2 @@
+ALTER USER foo IDENTIFIED AT LDAP AS 'cn=foo,dc=example,dc=com';
+SELECT DISTINGUISHED_NAME, KERBEROS_PRINCIPAL, OPENID_SUBJECT FROM SYS.EXA_DBA_USERS WHERE UPPER(USER_NAME) = UPPER(?); -- ["foo"]
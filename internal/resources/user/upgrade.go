@@ -0,0 +1,30 @@
+package user
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceV0 is the User schema before versioning was introduced
+func resourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"kerberos": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ldap": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
@@ -12,10 +12,14 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// authMethods are mutually exclusive ways for a User to authenticate
+var authMethods = []string{"ldap", "kerberos", "openid_subject", "password"}
+
 // Resource for Exasol User
 func Resource() *schema.Resource {
 	return &schema.Resource{
@@ -29,20 +33,34 @@ func Resource() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				Description:  "",
-				ExactlyOneOf: []string{"ldap", "kerberos", "password"},
+				Description:  "Authentication using password. Cannot be read back from the database",
+				ExactlyOneOf: authMethods,
 			},
 			"kerberos": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Description:  "Authentication using Kerberos Principals. The defined principal looks like <user>@<realm>",
-				ExactlyOneOf: []string{"ldap", "kerberos", "password"},
+				ExactlyOneOf: authMethods,
 			},
 			"ldap": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Description:  "Authentication using LDAP",
-				ExactlyOneOf: []string{"ldap", "kerberos", "password"},
+				ExactlyOneOf: authMethods,
+			},
+			"openid_subject": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Authentication using OpenID. The subject is matched against the token's subject claim. Requires Exasol 7.1",
+				ExactlyOneOf: authMethods,
+			},
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resource.UpgradeGlobalIDV0,
 			},
 		},
 		CreateContext: create,
@@ -80,22 +98,12 @@ func createData(d internal.Data, tx *sql.Tx) error {
 		return err
 	}
 
-	var stmt string
-
-	password := d.Get("password")
-	kerberos := d.Get("kerberos")
-	ldap := d.Get("ldap")
-
-	if password != "" {
-		stmt = fmt.Sprintf(`CREATE USER %s IDENTIFIED BY "%s"`, name, password)
-	} else if kerberos != "" {
-		stmt = fmt.Sprintf(`CREATE USER %s IDENTIFIED BY KERBEROS PRINCIPAL '%s'`, name, password)
-	} else if ldap != "" {
-		stmt = fmt.Sprintf(`CREATE USER %s IDENTIFIED AT LDAP AS '%s'`, name, password)
-	} else {
-		return errors.New("no identification found")
+	identification, err := identification(d)
+	if err != nil {
+		return err
 	}
 
+	stmt := fmt.Sprintf("CREATE USER %s %s", name, identification)
 	_, err = tx.Exec(stmt)
 	if err != nil {
 		return err
@@ -104,6 +112,24 @@ func createData(d internal.Data, tx *sql.Tx) error {
 	return err
 }
 
+// identification renders the IDENTIFIED clause of the configured
+// authentication method
+func identification(d internal.Data) (string, error) {
+	if password, _ := d.Get("password").(string); password != "" {
+		return "IDENTIFIED BY " + db.QuotedIdentifier(password), nil
+	}
+	if kerberos, _ := d.Get("kerberos").(string); kerberos != "" {
+		return "IDENTIFIED BY KERBEROS PRINCIPAL " + db.StringLiteral(kerberos), nil
+	}
+	if ldap, _ := d.Get("ldap").(string); ldap != "" {
+		return "IDENTIFIED AT LDAP AS " + db.StringLiteral(ldap), nil
+	}
+	if subject, _ := d.Get("openid_subject").(string); subject != "" {
+		return "IDENTIFIED BY OPENID SUBJECT " + db.StringLiteral(subject), nil
+	}
+	return "", errors.New("no identification found")
+}
+
 func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := globallock.RunAndRetryRollbacks(func() error {
 		c := meta.(*exaprovider.Client)
@@ -143,14 +169,18 @@ func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*sche
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	err := importData(ctx, d, locked.Tx)
+	version, err := c.Version(ctx, locked.Tx)
+	if err != nil {
+		return nil, err
+	}
+	err = importData(ctx, d, locked.Tx, version)
 	if err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
 
-func importData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+func importData(ctx context.Context, d internal.Data, tx *sql.Tx, version db.Version) error {
 	name := d.Id()
 	if name == "" {
 		return errors.New("import expects id to be set")
@@ -160,8 +190,8 @@ func importData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
 		return err
 	}
 
-	err = readData(ctx, d, tx)
-	if errors.Is(err, db.ErrorNamedObjectNotFound) {
+	err = readData(ctx, d, tx, version)
+	if db.IsNotFound(err) {
 		return fmt.Errorf("could not find User %s", name)
 	}
 	return err
@@ -171,66 +201,62 @@ func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Di
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	err := readData(ctx, d, locked.Tx)
+	version, err := c.Version(ctx, locked.Tx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return nil
+	err = readData(ctx, d, locked.Tx, version)
+	return diag.FromErr(resource.RemoveIfNotFound(ctx, d, err))
 }
 
-func readData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+// readData reads the authentication of the User. OPENID_SUBJECT is
+// only read from version 7.1 on.
+func readData(ctx context.Context, d internal.Data, tx *sql.Tx, version db.Version) error {
 	name, err := argument.Name(d)
 	if err != nil {
 		return err
 	}
 
-	res, err := tx.QueryContext(ctx, "SELECT DISTINGUISHED_NAME, KERBEROS_PRINCIPAL FROM SYS.EXA_DBA_USERS WHERE UPPER(USER_NAME) = UPPER(?)", name)
+	openIDColumn := "NULL"
+	if version.AtLeast(7, 1) {
+		openIDColumn = "OPENID_SUBJECT"
+	}
+
+	stmt := fmt.Sprintf("SELECT DISTINGUISHED_NAME, KERBEROS_PRINCIPAL, %s FROM SYS.EXA_DBA_USERS WHERE UPPER(USER_NAME) = UPPER(?)", openIDColumn)
+	res, err := tx.QueryContext(ctx, stmt, name)
 	if err != nil {
 		return err
 	}
+	defer res.Close()
 
 	if !res.Next() {
-		return db.ErrorNamedObjectNotFound
+		return db.NewNotFoundError("USER", name)
 	}
 
-	var ldapIf interface{}
-	var kerberosIf interface{}
-
-	err = res.Scan(&ldapIf, &kerberosIf)
+	var ldap, kerberos, openID sql.NullString
+	err = res.Scan(&ldap, &kerberos, &openID)
 	if err != nil {
 		return err
 	}
-	if ldapIf != nil {
-		err = d.Set("ldap", ldapIf.(string))
-		if err != nil {
-			return err
-		}
-		err = d.Set("kerberos", nil)
-		if err != nil {
-			return err
-		}
-		err = d.Set("password", nil)
-		if err != nil {
-			return err
-		}
-	} else if kerberosIf != nil {
-		err = d.Set("ldap", nil)
-		if err != nil {
-			return err
-		}
-		err = d.Set("kerberos", kerberosIf.(string))
-		if err != nil {
-			return err
-		}
-		err = d.Set("password", nil)
+
+	values := map[string]string{
+		"ldap":           ldap.String,
+		"kerberos":       kerberos.String,
+		"openid_subject": openID.String,
+	}
+	for attr, value := range values {
+		err = d.Set(attr, value)
 		if err != nil {
 			return err
 		}
-	} else {
-		//TODO: implement
 	}
-
-	return nil
+	if ldap.Valid || kerberos.Valid || openID.Valid {
+		return d.Set("password", "")
+	}
+	// Password Users cannot be read back. Keep the applied
+	// password so that only configuration changes are detected.
+	password, _ := d.Get("password").(string)
+	return d.Set("password", password)
 }
 
 func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -238,7 +264,11 @@ func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 	err := globallock.RunAndRetryRollbacks(func() error {
 		locked := c.Lock(ctx)
 		defer locked.Unlock()
-		err := updateData(ctx, d, locked.Tx)
+		version, err := c.Version(ctx, locked.Tx)
+		if err != nil {
+			return err
+		}
+		err = updateData(ctx, d, locked.Tx, version)
 		if err != nil {
 			return err
 		}
@@ -251,7 +281,7 @@ func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 	return nil
 }
 
-func updateData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+func updateData(ctx context.Context, d internal.Data, tx *sql.Tx, version db.Version) error {
 
 	if d.HasChange("name") {
 		old, new := d.GetChange("name")
@@ -262,5 +292,30 @@ func updateData(ctx context.Context, d internal.Data, tx *sql.Tx) error {
 		}
 	}
 
-	return readData(ctx, d, tx)
+	if hasAuthChange(d) {
+		name, err := argument.Name(d)
+		if err != nil {
+			return err
+		}
+		identification, err := identification(d)
+		if err != nil {
+			return err
+		}
+		stmt := fmt.Sprintf("ALTER USER %s %s", name, identification)
+		_, err = tx.Exec(stmt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return readData(ctx, d, tx, version)
+}
+
+func hasAuthChange(d internal.Data) bool {
+	for _, method := range authMethods {
+		if d.HasChange(method) {
+			return true
+		}
+	}
+	return false
 }
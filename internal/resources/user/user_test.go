@@ -0,0 +1,78 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+)
+
+func TestImportAuthMethods(t *testing.T) {
+	t.Parallel()
+
+	methods := map[string]string{
+		"ldap":           "cn=foo,dc=example,dc=com",
+		"kerberos":       "foo@EXAMPLE.COM",
+		"openid_subject": "foo@example.com",
+		"password":       "secret",
+	}
+	for method, value := range methods {
+		method := method
+		value := value
+		t.Run(method, func(t *testing.T) {
+			name := fmt.Sprintf("%s_%s", strings.ReplaceAll(t.Name(), "/", "_"), nameSuffix)
+			err := globallock.RunAndRetryRollbacks(func() error {
+				locked := exaprovider.TestLock(t, exaClient)
+				defer locked.Unlock()
+
+				create := &internal.TestData{
+					Values: map[string]interface{}{
+						"name": name,
+					},
+				}
+				for _, m := range authMethods {
+					create.Values[m] = ""
+				}
+				create.Values[method] = value
+				err := createData(create, locked.Tx)
+				if err != nil {
+					if globallock.IsRollbackError(err) {
+						return err
+					}
+					t.Fatal("Unexpected error:", err)
+				}
+
+				imp := &internal.TestData{}
+				imp.SetId(create.Id())
+				version, err := exaClient.Version(context.TODO(), locked.Tx)
+				if err != nil {
+					t.Fatal("Unexpected error:", err)
+				}
+				err = importData(context.TODO(), imp, locked.Tx, version)
+				if err != nil {
+					if globallock.IsRollbackError(err) {
+						return err
+					}
+					t.Fatal("Unexpected error:", err)
+				}
+				for _, m := range authMethods {
+					expected := ""
+					if m == method && m != "password" {
+						expected = value
+					}
+					if imp.Get(m) != expected {
+						t.Fatalf("Expected %s to be %#v but got %#v", m, expected, imp.Get(m))
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
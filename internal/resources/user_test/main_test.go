@@ -5,7 +5,7 @@ import (
 	"os"
 	"testing"
 
-	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
 	"github.com/exasol/exasol-driver-go"
 )
 
@@ -15,6 +15,6 @@ var (
 
 func TestMain(m *testing.M) {
 	flag.Parse()
-	exaConf = internal.MustCreateTestConf()
+	exaConf = testclient.MustCreateTestConf()
 	os.Exit(m.Run())
 }
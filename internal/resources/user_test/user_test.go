@@ -27,8 +27,8 @@ func TestAccExasolUser_rename(t *testing.T) {
 	ps := test.NewDefaultAccProviders()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          nil,
-		ProviderFactories: ps.Factories,
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: ps.Factories,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`%s
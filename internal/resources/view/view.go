@@ -46,8 +46,9 @@ func Resource() *schema.Resource {
 			},
 			"schema": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Schema to create View in",
+				Optional:    true,
+				Computed:    true,
+				Description: "Schema to create View in. Defaults to default_schema of the provider.",
 				ForceNew:    true,
 			},
 			"column": {
@@ -75,7 +76,17 @@ func Resource() *schema.Resource {
 				Default:     false,
 				Description: "Allows for replacing View inplace",
 			},
+			"object_name": argument.ObjectNameSchema(),
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resource.UpgradeSchemaObjectIDV0,
+			},
 		},
+		CustomizeDiff: argument.CustomizeDefaults(exaprovider.ArgumentDefaults),
 		CreateContext: create,
 		ReadContext:   read,
 		UpdateContext: update,
@@ -91,8 +102,8 @@ type RequiredCreateArguments struct {
 	subquery string
 }
 
-func requiredCreateArguments(d *schema.ResourceData) (RequiredCreateArguments, diag.Diagnostics) {
-	ra, diags := argument.ExtractRequiredArguments(d)
+func requiredCreateArguments(d *schema.ResourceData, defaults argument.Defaults) (RequiredCreateArguments, diag.Diagnostics) {
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, defaults)
 	subquery, ok := d.Get("subquery").(string)
 	if !ok {
 		diags = append(diags, diag.Diagnostic{
@@ -113,7 +124,7 @@ func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ca, diags := requiredCreateArguments(d)
+	ca, diags := requiredCreateArguments(d, c.Defaults)
 	if diags.HasError() {
 		return diags
 	}
@@ -169,6 +180,16 @@ func createData(ctx context.Context, d *schema.ResourceData, tx *sql.Tx, args Re
 		return append(diags, diag.FromErr(err)...)
 	}
 
+	err = d.Set("schema", args.Schema)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	err = d.Set("object_name", args.Name)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
 	d.SetId(resource.NewID(args.Schema, args.Name))
 	return diags
 }
@@ -177,7 +198,7 @@ func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ra, diags := argument.ExtractRequiredArguments(d)
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, c.Defaults)
 	if diags.HasError() {
 		return diags
 	}
@@ -207,7 +228,7 @@ func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*sche
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	err := importData(ctx, d, locked.Tx)
+	err := importData(ctx, d, locked.Tx, c.Defaults)
 	if err != nil {
 		return nil, err
 	}
@@ -218,10 +239,15 @@ func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*sche
 	return []*schema.ResourceData{d}, nil
 }
 
-func importData(ctx context.Context, d *schema.ResourceData, tx *sql.Tx) error {
+func importData(ctx context.Context, d *schema.ResourceData, tx *sql.Tx, defaults argument.Defaults) error {
 	id := d.Id()
 
-	m, err := resource.GetMetaFromQNDefault(id, d.Get("schema").(string))
+	schemaDefault := d.Get("schema").(string)
+	if schemaDefault == "" {
+		schemaDefault = defaults.Schema
+	}
+
+	m, err := resource.GetMetaFromQNDefault(id, schemaDefault)
 	if err != nil {
 		return err
 	}
@@ -230,7 +256,7 @@ func importData(ctx context.Context, d *schema.ResourceData, tx *sql.Tx) error {
 		return errors.New("missing schema in import")
 	}
 
-	err = d.Set("name", m.ObjectName)
+	err = d.Set("name", resource.TrimPrefix(m.ObjectName, defaults.ObjectPrefix))
 	if err != nil {
 		return err
 	}
@@ -238,6 +264,10 @@ func importData(ctx context.Context, d *schema.ResourceData, tx *sql.Tx) error {
 	if err != nil {
 		return err
 	}
+	err = d.Set("object_name", m.ObjectName)
+	if err != nil {
+		return err
+	}
 
 	tv, err := computed.ReadView(ctx, tx, m.Schema, m.ObjectName)
 	if err != nil {
@@ -257,7 +287,7 @@ func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Di
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ra, diags := argument.ExtractRequiredArguments(d)
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, c.Defaults)
 	if diags.HasError() {
 		return diags
 	}
@@ -267,11 +297,8 @@ func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Di
 func readData(ctx context.Context, d *schema.ResourceData, tx *sql.Tx, args argument.RequiredArguments) diag.Diagnostics {
 
 	tr, err := computed.ReadView(ctx, tx, args.Schema, args.Name)
-	if errors.Is(err, computed.ReadViewNoResultError) {
-		d.SetId("")
-		return nil
-	} else if err != nil {
-		return diag.FromErr(err)
+	if err != nil {
+		return diag.FromErr(resource.RemoveIfNotFound(ctx, d, err))
 	}
 
 	err = tr.SetComment(d)
@@ -289,6 +316,11 @@ func readData(ctx context.Context, d *schema.ResourceData, tx *sql.Tx, args argu
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("object_name", args.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -296,7 +328,7 @@ func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.
 	c := meta.(*exaprovider.Client)
 	locked := c.Lock(ctx)
 	defer locked.Unlock()
-	ra, diags := argument.ExtractRequiredArguments(d)
+	ra, diags := argument.ExtractRequiredArgumentsWithDefaults(d, c.Defaults)
 	if diags.HasError() {
 		return diags
 	}
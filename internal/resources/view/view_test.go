@@ -129,7 +129,7 @@ func TestViewResourceImport(t *testing.T) {
 	imp.Set("subquery", "SELECT COLUMN_NAME FROM SYS.EXA_ALL_COLUMNS")
 	imp.SetId(name)
 
-	err := importData(context.TODO(), imp, locked.Tx)
+	err := importData(context.TODO(), imp, locked.Tx, argument.Defaults{})
 	if err != nil {
 		t.Fatal("Unexpected error:", err)
 	}
@@ -7,9 +7,9 @@ import (
 	"os"
 	"testing"
 
-	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
 	"github.com/abergmeier/terraform-provider-exasol/internal/resourceprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/testclient"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
 	"github.com/exasol/exasol-driver-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -43,7 +43,7 @@ func TestMain(m *testing.M) {
 }
 
 func testRun(m *testing.M) int {
-	exaConf = internal.MustCreateTestConf()
+	exaConf = testclient.MustCreateTestConf()
 	exaClient = exaprovider.NewClient(exaConf)
 
 	func() {
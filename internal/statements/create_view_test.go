@@ -0,0 +1,41 @@
+package statements
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/sqlrecorder"
+)
+
+func TestCreateViewGolden(t *testing.T) {
+	tests := map[string]*CreateView{
+		"create_view": {
+			Schema:   "S",
+			Name:     "V",
+			Subquery: "SELECT 1 AS A FROM DUAL",
+		},
+		"replace_view_columns": {
+			Schema: "S",
+			Name:   "V",
+			Columns: []ViewColumn{
+				{Name: "A", Comment: "first"},
+				{Name: "B"},
+			},
+			Subquery: "SELECT 1, 2 FROM DUAL",
+			Comment:  "view",
+			Replace:  true,
+		},
+	}
+
+	for name, stmt := range tests {
+		stmt := stmt
+		t.Run(name, func(t *testing.T) {
+			rec, tx := sqlrecorder.New(t)
+			err := stmt.Execute(context.Background(), tx)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			rec.AssertGolden(t, name)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package statements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	// scriptExp matches statements with a body in another language
+	// or PL/SQL which may contain semicolons and unbalanced quotes
+	scriptExp = regexp.MustCompile(`(?i)^CREATE\s+(OR\s+REPLACE\s+)?(FUNCTION|([A-Z0-9_]+\s+)?((SCALAR|SET|ADAPTER)\s+)?SCRIPT)\s`)
+	// scriptEndExp matches the line terminating a body like in EXAplus
+	scriptEndExp = regexp.MustCompile(`(?m)^[ \t]*/[ \t]*\r?$`)
+)
+
+// Script is a sequence of statements separated by semicolons.
+// Like in EXAplus, statements creating scripts or functions end with a
+// line only containing / instead.
+type Script struct {
+	SQL string
+}
+
+// Statements splits the Script at semicolons outside of quotes and comments.
+// Bodies of scripts and functions are kept as is up to a line only
+// containing / or the end of the Script.
+// Empty statements are dropped.
+func (s *Script) Statements() ([]string, error) {
+	stmts := []string{}
+	query := s.SQL
+	start := 0
+	// begin is true until the current statement has more than
+	// whitespace and comments
+	begin := true
+	add := func(end, next int) {
+		stmt := strings.TrimSpace(query[start:end])
+		if stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		start = next
+		begin = true
+	}
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if begin && scriptExp.MatchString(query[i:]) {
+			loc := scriptEndExp.FindStringIndex(query[i:])
+			if loc == nil {
+				add(len(query), len(query))
+				break
+			}
+			add(i+loc[0], i+loc[1])
+			i = start - 1
+			continue
+		}
+		switch {
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				i = len(query)
+			} else {
+				i += end
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				return nil, errors.New("unterminated comment")
+			}
+			i += end + 3
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(query[i+1:], c)
+			if end == -1 {
+				return nil, errors.New("unterminated quote")
+			}
+			i += end + 1
+			begin = false
+		case c == ';':
+			add(i, i+1)
+		case c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			begin = false
+		}
+	}
+	if start < len(query) {
+		add(len(query), len(query))
+	}
+	return stmts, nil
+}
+
+// Execute runs all statements of the Script in order
+func (s *Script) Execute(ctx context.Context, tx *sql.Tx) error {
+	stmts, err := s.Statements()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		_, err = tx.ExecContext(ctx, stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
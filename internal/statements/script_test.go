@@ -0,0 +1,74 @@
+package statements
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScriptStatements(t *testing.T) {
+	tests := map[string][]string{
+		"":                        {},
+		"CREATE SCHEMA A":         {"CREATE SCHEMA A"},
+		"CREATE SCHEMA A;":        {"CREATE SCHEMA A"},
+		" ; ;CREATE SCHEMA A ; ;": {"CREATE SCHEMA A"},
+		"CREATE SCHEMA A;\nDROP SCHEMA B;": {
+			"CREATE SCHEMA A",
+			"DROP SCHEMA B",
+		},
+		"COMMENT ON SCHEMA A IS 'a;b'; SELECT 1": {
+			"COMMENT ON SCHEMA A IS 'a;b'",
+			"SELECT 1",
+		},
+		"CREATE SCHEMA \"A;B\"": {"CREATE SCHEMA \"A;B\""},
+		"CREATE SCHEMA A -- first;\n;DROP SCHEMA B /* ; */": {
+			"CREATE SCHEMA A -- first;",
+			"DROP SCHEMA B /* ; */",
+		},
+		"CREATE SCHEMA A;\nCREATE OR REPLACE PYTHON3 SCALAR SCRIPT A.S(x INT) RETURNS INT AS\n# don't split; here\ndef run(ctx):\n    return ctx.x\n/\nDROP SCHEMA B;": {
+			"CREATE SCHEMA A",
+			"CREATE OR REPLACE PYTHON3 SCALAR SCRIPT A.S(x INT) RETURNS INT AS\n# don't split; here\ndef run(ctx):\n    return ctx.x",
+			"DROP SCHEMA B",
+		},
+		"create function A.F (p NUMBER) RETURN NUMBER IS\nBEGIN\n  RETURN p / 2;\nEND F;\n /\n": {
+			"create function A.F (p NUMBER) RETURN NUMBER IS\nBEGIN\n  RETURN p / 2;\nEND F;",
+		},
+		"CREATE LUA SCRIPT A.L AS\nquery([[SELECT 1; SELECT 2]])": {
+			"CREATE LUA SCRIPT A.L AS\nquery([[SELECT 1; SELECT 2]])",
+		},
+		"-- UDF\nCREATE JAVA SET SCRIPT A.J() EMITS (x INT) AS\nclass J { static void run() {}; }\n/": {
+			"-- UDF\nCREATE JAVA SET SCRIPT A.J() EMITS (x INT) AS\nclass J { static void run() {}; }",
+		},
+		"CREATE TABLE A.SCRIPTS (A INT); SELECT 1": {
+			"CREATE TABLE A.SCRIPTS (A INT)",
+			"SELECT 1",
+		},
+	}
+
+	for script, expected := range tests {
+		s := &Script{
+			SQL: script,
+		}
+		stmts, err := s.Statements()
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %s", script, err)
+		}
+		if !reflect.DeepEqual(stmts, expected) {
+			t.Errorf("Unexpected statements for %q: %#v", script, stmts)
+		}
+	}
+}
+
+func TestScriptStatementsUnterminated(t *testing.T) {
+	for _, script := range []string{
+		"COMMENT ON SCHEMA A IS 'a",
+		"CREATE SCHEMA A /* b",
+	} {
+		s := &Script{
+			SQL: script,
+		}
+		_, err := s.Statements()
+		if err == nil {
+			t.Errorf("Expected error for %q", script)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package statements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	// ErrorNotSelect is returned for statements other than a single SELECT
+	ErrorNotSelect = errors.New("only a single SELECT statement is allowed")
+)
+
+// Select is a read-only query with bind parameters
+type Select struct {
+	Query   string
+	Args    []interface{}
+	MaxRows int
+}
+
+// Validate ensures Query is a single SELECT (or WITH ... SELECT) statement
+func (s *Select) Validate() error {
+	q, err := stripStatement(s.Query)
+	if err != nil {
+		return err
+	}
+	q = strings.TrimLeft(q, "( \t\r\n")
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return ErrorNotSelect
+	}
+	keyword := strings.ToUpper(words[0])
+	if keyword != "SELECT" && keyword != "WITH" {
+		return ErrorNotSelect
+	}
+	return nil
+}
+
+// Execute runs the query and returns every row as map of column name
+// to value. Errors if the query returns more than MaxRows rows.
+func (s *Select) Execute(ctx context.Context, tx *sql.Tx) ([]string, []map[string]string, error) {
+	err := s.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := tx.QueryContext(ctx, s.Query, s.Args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Close()
+
+	columns, err := res.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := []map[string]string{}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for res.Next() {
+		if len(rows) == s.MaxRows {
+			return nil, nil, fmt.Errorf("query returned more than %d rows", s.MaxRows)
+		}
+		err = res.Scan(pointers...)
+		if err != nil {
+			return nil, nil, err
+		}
+		row := map[string]string{}
+		for i, c := range columns {
+			if values[i] == nil {
+				// Terraform maps cannot hold null
+				continue
+			}
+			row[c] = formatValue(values[i])
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, res.Err()
+}
+
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case bool:
+		return strconv.FormatBool(t)
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// stripStatement removes comments and a trailing semicolon.
+// Errors if query contains more than one statement.
+func stripStatement(query string) (string, error) {
+	b := &strings.Builder{}
+	ended := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				i = len(query)
+			} else {
+				i += end
+			}
+			b.WriteByte(' ')
+			continue
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				return "", errors.New("unterminated comment")
+			}
+			i += end + 3
+			b.WriteByte(' ')
+			continue
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(query[i+1:], c)
+			if end == -1 {
+				return "", errors.New("unterminated quote")
+			}
+			if ended {
+				return "", ErrorNotSelect
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+			continue
+		case c == ';':
+			if ended {
+				return "", ErrorNotSelect
+			}
+			ended = true
+			continue
+		}
+
+		if ended && !unicode.IsSpace(rune(c)) {
+			return "", ErrorNotSelect
+		}
+		b.WriteByte(c)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
@@ -0,0 +1,42 @@
+package statements
+
+import "testing"
+
+func TestSelectValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{
+		"SELECT 1",
+		"select * from foo where a = ?;",
+		"  -- version\nSELECT PARAM_VALUE FROM EXA_METADATA",
+		"/* partitions */ WITH p AS (SELECT 1) SELECT * FROM p",
+		"(SELECT 1) UNION (SELECT 2)",
+		"SELECT 'a;b' AS x, \"semi;colon\" FROM dual ;  ",
+	}
+	for _, q := range valid {
+		s := &Select{Query: q}
+		err := s.Validate()
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", q, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"DELETE FROM foo",
+		"INSERT INTO foo SELECT 1",
+		"SELECT 1; DROP TABLE foo",
+		"SELECT 1; SELECT 2",
+		"-- SELECT\nDROP SCHEMA foo",
+		"/* unterminated SELECT",
+		"SELECT 'unterminated",
+		"SELECTED",
+	}
+	for _, q := range invalid {
+		s := &Select{Query: q}
+		err := s.Validate()
+		if err == nil {
+			t.Errorf("Expected error for %s", q)
+		}
+	}
+}
@@ -0,0 +1 @@
+CREATE VIEW S.V AS SELECT 1 AS A FROM DUAL;
@@ -0,0 +1 @@
+CREATE OR REPLACE VIEW S.V (A COMMENT IS 'first', B) AS SELECT 1, 2 FROM DUAL COMMENT IS 'view';
@@ -9,6 +9,7 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
 	"github.com/abergmeier/terraform-provider-exasol/internal/resourceprovider"
 	"github.com/exasol/exasol-driver-go"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -20,16 +21,20 @@ var (
 
 type DefaultAccProviders struct {
 	Exasol    *schema.Provider
-	Factories map[string]func() (*schema.Provider, error)
+	Factories map[string]func() (tfprotov6.ProviderServer, error)
 }
 
 func NewDefaultAccProviders() DefaultAccProviders {
 	p := resourceprovider.Provider()
 	return DefaultAccProviders{
 		Exasol: p,
-		Factories: map[string]func() (*schema.Provider, error){
-			"exasol": func() (*schema.Provider, error) {
-				return p, nil
+		Factories: map[string]func() (tfprotov6.ProviderServer, error){
+			"exasol": func() (tfprotov6.ProviderServer, error) {
+				serverFunc, err := resourceprovider.MuxServer(context.TODO(), p)
+				if err != nil {
+					return nil, err
+				}
+				return serverFunc(), nil
 			},
 		},
 	}
@@ -0,0 +1,281 @@
+// Package sqlrecorder provides a database/sql driver that records
+// statements instead of executing them. Together with golden files
+// it tests the SQL generated by Resources without a database.
+package sqlrecorder
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+var update = flag.Bool("update", false, "update golden files of sqlrecorder")
+
+// Statement is a single recorded Exec or Query
+type Statement struct {
+	SQL  string
+	Args []driver.Value
+}
+
+// Rows are returned for queries matching a registered prefix
+type Rows struct {
+	Columns []string
+	Values  [][]driver.Value
+}
+
+// Recorder records all statements sent through its connections
+type Recorder struct {
+	mu         sync.Mutex
+	statements []Statement
+	rows       map[string]Rows
+	errs       map[string]error
+}
+
+// New creates a Recorder and a Transaction on it. The Transaction
+// is rolled back when the test finishes.
+func New(t *testing.T) (*Recorder, *sql.Tx) {
+	t.Helper()
+	r := &Recorder{
+		rows: map[string]Rows{},
+		errs: map[string]error{},
+	}
+	db := sql.OpenDB(r)
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	t.Cleanup(func() {
+		tx.Rollback()
+		db.Close()
+	})
+	return r, tx
+}
+
+// AddRows registers rows returned for queries starting with prefix.
+// Queries without registered rows return no rows.
+func (r *Recorder) AddRows(prefix string, columns []string, values ...[]driver.Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rows[prefix] = Rows{
+		Columns: columns,
+		Values:  values,
+	}
+}
+
+// AddError registers an error returned for statements starting with prefix
+func (r *Recorder) AddError(prefix string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs[prefix] = err
+}
+
+// Statements returns all recorded statements in order
+func (r *Recorder) Statements() []Statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Statement(nil), r.statements...)
+}
+
+// String renders the recorded statements one per line. Arguments
+// are appended as JSON comment.
+func (r *Recorder) String() string {
+	var sb strings.Builder
+	for _, s := range r.Statements() {
+		sb.WriteString(s.SQL)
+		sb.WriteString(";")
+		if len(s.Args) != 0 {
+			args, _ := json.Marshal(s.Args)
+			fmt.Fprintf(&sb, " -- %s", args)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// AssertGolden compares the recorded statements with testdata/<name>.sql.
+// Run tests with -update to write the golden file instead.
+func (r *Recorder) AssertGolden(t *testing.T, name string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".sql")
+	actual := r.String()
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		err = os.WriteFile(path, []byte(actual), 0644)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Reading golden file failed (run with -update to create it): %s", err)
+	}
+	if string(expected) != actual {
+		t.Errorf("Statements differ from %s:\n%v", path, diff.LineDiff(string(expected), actual))
+	}
+}
+
+func (r *Recorder) record(query string, args []driver.NamedValue) error {
+	values := make([]driver.Value, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, Statement{
+		SQL:  strings.TrimSpace(query),
+		Args: values,
+	})
+	for prefix, err := range r.errs {
+		if strings.HasPrefix(query, prefix) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Recorder) lookupRows(query string) Rows {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := ""
+	for prefix := range r.rows {
+		if strings.HasPrefix(query, prefix) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	if match == "" {
+		return Rows{}
+	}
+	return r.rows[match]
+}
+
+// Connect implements driver.Connector
+func (r *Recorder) Connect(ctx context.Context) (driver.Conn, error) {
+	return &conn{r: r}, nil
+}
+
+// Driver implements driver.Connector
+func (r *Recorder) Driver() driver.Driver {
+	return recorderDriver{r: r}
+}
+
+type recorderDriver struct {
+	r *Recorder
+}
+
+func (d recorderDriver) Open(name string) (driver.Conn, error) {
+	return &conn{r: d.r}, nil
+}
+
+type conn struct {
+	r *Recorder
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{c: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	err := c.r.record(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	err := c.r.record(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{Rows: c.r.lookupRows(strings.TrimSpace(query))}, nil
+}
+
+// stmt records when executed, not when prepared
+type stmt struct {
+	c     *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("sqlrecorder only supports ExecContext")
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("sqlrecorder only supports QueryContext")
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.c.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.c.QueryContext(ctx, s.query, args)
+}
+
+type tx struct{}
+
+func (tx) Commit() error {
+	return nil
+}
+
+func (tx) Rollback() error {
+	return nil
+}
+
+type rows struct {
+	Rows
+	i int
+}
+
+func (r *rows) Columns() []string {
+	return r.Rows.Columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.i >= len(r.Values) {
+		return io.EOF
+	}
+	copy(dest, r.Values[r.i])
+	r.i++
+	return nil
+}
@@ -0,0 +1,38 @@
+package sqlrecorder
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	rec, tx := New(t)
+	rec.AddRows("SELECT NAME", []string{"NAME"}, []driver.Value{"foo"}, []driver.Value{"bar"})
+
+	_, err := tx.Exec("CREATE ROLE foo")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	rows, err := tx.Query("SELECT NAME FROM T WHERE ID = ?", 1)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	names := []string{}
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if len(names) != 2 || names[0] != "foo" || names[1] != "bar" {
+		t.Fatalf("Unexpected rows %v", names)
+	}
+
+	expected := "CREATE ROLE foo;\nSELECT NAME FROM T WHERE ID = ?; -- [1]\n"
+	if rec.String() != expected {
+		t.Fatalf("Expected %q but got %q", expected, rec.String())
+	}
+}
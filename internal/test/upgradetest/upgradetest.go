@@ -0,0 +1,71 @@
+// Package upgradetest runs the StateUpgraders of a Resource against
+// historic state stored in testdata. The historic state has to match
+// the type of the upgrader and the upgraded state has to match the
+// current schema of the Resource.
+package upgradetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Upgrade upgrades the state in testdata/<fixture> from version to the
+// current SchemaVersion of r
+func Upgrade(t *testing.T, r *schema.Resource, version int, fixture string) map[string]interface{} {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatal("Reading fixture failed:", err)
+	}
+
+	rawState := map[string]interface{}{}
+	err = json.Unmarshal(content, &rawState)
+	if err != nil {
+		t.Fatal("Parsing fixture failed:", err)
+	}
+
+	for v := version; v < r.SchemaVersion; v++ {
+		upgrader := findUpgrader(t, r, v)
+		assertType(t, upgrader.Type, rawState, fmt.Sprintf("historic state of version %d", v))
+		rawState, err = upgrader.Upgrade(context.TODO(), rawState, nil)
+		if err != nil {
+			t.Fatalf("Upgrading state of version %d failed: %s", v, err)
+		}
+	}
+
+	assertType(t, r.CoreConfigSchema().ImpliedType(), rawState, "upgraded state")
+	return rawState
+}
+
+func findUpgrader(t *testing.T, r *schema.Resource, version int) schema.StateUpgrader {
+	t.Helper()
+	for _, u := range r.StateUpgraders {
+		if u.Version == version {
+			return u
+		}
+	}
+	t.Fatalf("No StateUpgrader for version %d", version)
+	return schema.StateUpgrader{}
+}
+
+// assertType decodes rawState like Terraform does. Attributes missing
+// from typ fail decoding.
+func assertType(t *testing.T, typ cty.Type, rawState map[string]interface{}, what string) {
+	t.Helper()
+	content, err := json.Marshal(rawState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ctyjson.Unmarshal(content, typ)
+	if err != nil {
+		t.Fatalf("Unexpected %s %s: %s", what, content, err)
+	}
+}
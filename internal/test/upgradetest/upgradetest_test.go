@@ -0,0 +1,96 @@
+package upgradetest_test
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources"
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources/connection"
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources/role"
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources/table"
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources/user"
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources/view"
+	"github.com/abergmeier/terraform-provider-exasol/internal/test/upgradetest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestUpgradeV0(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resource *schema.Resource
+		fixture  string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "connection",
+			resource: connection.Resource(),
+			fixture:  "connection_state_v0.json",
+			expected: map[string]interface{}{
+				"id":   "MY_FTP",
+				"name": "my_ftp",
+			},
+		},
+		{
+			name:     "physical_schema",
+			resource: resources.PhysicalSchema(),
+			fixture:  "physical_schema_state_v0.json",
+			expected: map[string]interface{}{
+				"id":   "DEV",
+				"name": "dev",
+			},
+		},
+		{
+			name:     "role",
+			resource: role.Resource(),
+			fixture:  "role_state_v0.json",
+			expected: map[string]interface{}{
+				"id":   "REPORTING",
+				"name": "reporting",
+			},
+		},
+		{
+			name:     "table",
+			resource: table.Resource(),
+			fixture:  "table_state_v0.json",
+			expected: map[string]interface{}{
+				"id":        "FOO.BAR",
+				"replace":   false,
+				"composite": "a VARCHAR(20)",
+			},
+		},
+		{
+			name:     "user",
+			resource: user.Resource(),
+			fixture:  "user_state_v0.json",
+			expected: map[string]interface{}{
+				"id":   "ANALYST",
+				"name": "analyst",
+			},
+		},
+		{
+			name:     "view",
+			resource: view.Resource(),
+			fixture:  "view_state_v0.json",
+			expected: map[string]interface{}{
+				"id":       "DEV.ORDERS_V",
+				"schema":   "dev",
+				"subquery": "SELECT ID FROM DEV.ORDERS",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			upgraded := upgradetest.Upgrade(t, test.resource, 0, test.fixture)
+			for k, v := range test.expected {
+				if upgraded[k] != v {
+					t.Errorf("Unexpected %s: %#v", k, upgraded[k])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Package testclient connects tests to the Exasol at EXAHOST or to an
+// in-process fake database. Only tests may import it so that the fake
+// is not linked into the provider.
+package testclient
+
+import (
+	"os"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/fakeexasol"
+	"github.com/exasol/exasol-driver-go"
+)
+
+// MustCreateTestConf configures the Exasol at EXAHOST. Without EXAHOST
+// an in-process fake database is used.
+func MustCreateTestConf() *exasol.DSNConfig {
+	exaHost := os.Getenv("EXAHOST")
+	if exaHost == "" {
+		return fakeTestConf()
+	}
+	hosts, err := exaprovider.ParseHosts(exaHost)
+	if err != nil {
+		panic(err)
+	}
+	port := 8563
+	if hosts.Port != 0 {
+		port = hosts.Port
+	}
+
+	exaUID := os.Getenv("EXAUID")
+	if exaUID == "" {
+		exaUID = "sys"
+	}
+
+	exaPWD := os.Getenv("EXAPWD")
+	if exaPWD == "" {
+		exaPWD = "exasol"
+	}
+
+	autocommit := false
+	validate := false
+
+	return &exasol.DSNConfig{
+		User:                      exaUID,
+		Password:                  exaPWD,
+		Host:                      hosts.Host,
+		Port:                      port,
+		Autocommit:                &autocommit,
+		ValidateServerCertificate: &validate,
+	}
+	//LogLevel: "debug",
+}
+
+func fakeTestConf() *exasol.DSNConfig {
+	server := fakeexasol.Default()
+	autocommit := false
+	validate := false
+	return &exasol.DSNConfig{
+		User:                      fakeexasol.DefaultUser,
+		Password:                  fakeexasol.DefaultPassword,
+		Host:                      server.Host(),
+		Port:                      server.Port(),
+		Autocommit:                &autocommit,
+		ValidateServerCertificate: &validate,
+	}
+}
+
+func MustCreateTestClient() *exaprovider.Client {
+	return exaprovider.NewClient(MustCreateTestConf())
+}
@@ -3,8 +3,10 @@ package argument
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var (
@@ -41,3 +43,10 @@ func GetOkAsString(d internal.Data, name string) (string, bool) {
 	}
 	return i.(string), true
 }
+
+// SuppressCaseDiff ignores changes in case of identifiers which Exasol
+// resolves case insensitively. Prevents replacing imported Resources
+// whose configuration uses another case.
+func SuppressCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
@@ -0,0 +1,33 @@
+package argument
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+// NameRegex compiles name_regex of Data.
+// Returns nil if name_regex is not set.
+func NameRegex(d internal.Data) (*regexp.Regexp, error) {
+	return Regex(d, "name_regex")
+}
+
+// Regex compiles the regular expression in attribute name of Data.
+// Returns nil if the attribute is not set.
+func Regex(d internal.Data, name string) (*regexp.Regexp, error) {
+	expr, _ := d.Get(name).(string)
+	if expr == "" {
+		return nil, nil
+	}
+	r, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return r, nil
+}
+
+// MatchOptional reports whether s matches r. A nil r matches everything.
+func MatchOptional(r *regexp.Regexp, s string) bool {
+	return r == nil || r.MatchString(s)
+}
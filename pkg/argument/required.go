@@ -1,6 +1,10 @@
 package argument
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -8,23 +12,107 @@ import (
 type RequiredArguments struct {
 	Schema string
 	Name   string
+	// Prefix is the object prefix that Name already contains
+	Prefix string
+}
+
+// Defaults are provider wide fallbacks for arguments of Resources
+type Defaults struct {
+	// Schema is used for Resources that omit schema
+	Schema string
+	// ObjectPrefix is prepended to names of schema objects
+	ObjectPrefix string
+}
+
+// ObjectNameSchema records the name of a schema object including the
+// object prefix. See CustomizeDefaults.
+func ObjectNameSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the object in the database including object_prefix of the provider",
+	}
 }
 
 func ExtractRequiredArguments(d *schema.ResourceData) (RequiredArguments, diag.Diagnostics) {
+	return ExtractRequiredArgumentsWithDefaults(d, Defaults{})
+}
+
+// ExtractRequiredArgumentsWithDefaults falls back to the default schema
+// when schema is omitted and prefixes the name with the object prefix.
+// A name recorded in object_name wins over the prefixed name.
+func ExtractRequiredArgumentsWithDefaults(d internal.Data, defaults Defaults) (RequiredArguments, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	name, err := Name(d)
 	if err != nil {
 		diags = append(diags, diag.FromErr(err)...)
 	}
-	schema, err := Schema(d)
-	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+	schema, _ := d.Get("schema").(string)
+	if schema == "" {
+		schema = defaults.Schema
+	}
+	if schema == "" {
+		diags = append(diags, diag.FromErr(fmt.Errorf("Empty schema for %s and no default_schema configured", d.Id()))...)
 	}
 	if diags.HasError() {
 		return RequiredArguments{}, diags
 	}
+	objectName, _ := d.Get("object_name").(string)
+	if objectName == "" {
+		objectName = defaults.ObjectPrefix + name
+	}
 	return RequiredArguments{
 		Schema: schema,
-		Name:   name,
+		Name:   objectName,
+		Prefix: defaults.ObjectPrefix,
 	}, diags
 }
+
+// CustomizeDefaults plans schema and object_name resulting from the
+// Defaults returned for meta. Changing default_schema or object_prefix
+// of the provider thereby replaces a Resource instead of silently
+// retargeting it.
+func CustomizeDefaults(defaults func(meta interface{}) Defaults) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		df := defaults(meta)
+
+		if d.NewValueKnown("schema") && !configured(d, "schema") && df.Schema != "" {
+			if old, _ := d.Get("schema").(string); old != df.Schema {
+				// schema is ForceNew so existing Resources are replaced
+				err := d.SetNew("schema", df.Schema)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if !d.NewValueKnown("name") {
+			return d.SetNewComputed("object_name")
+		}
+		oldName, newName := d.GetChange("name")
+		recorded, _ := d.Get("object_name").(string)
+		objectName := df.ObjectPrefix + newName.(string)
+		if recorded == objectName {
+			return nil
+		}
+		err := d.SetNew("object_name", objectName)
+		if err != nil {
+			return err
+		}
+		if d.Id() == "" || recorded == "" || recorded == df.ObjectPrefix+oldName.(string) {
+			// Only name changed which Resources handle themselves
+			return nil
+		}
+		return d.ForceNew("object_name")
+	}
+}
+
+// configured reports whether key is set in the configuration. Without
+// raw configuration every key is considered configured.
+func configured(d *schema.ResourceDiff, key string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(key) {
+		return true
+	}
+	return !raw.GetAttr(key).IsNull()
+}
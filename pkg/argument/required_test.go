@@ -0,0 +1,95 @@
+package argument
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCustomizeDefaults(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"schema": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"object_name": ObjectNameSchema(),
+		},
+		CustomizeDiff: CustomizeDefaults(func(meta interface{}) Defaults {
+			return meta.(Defaults)
+		}),
+	}
+
+	state := func() *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "DEV.DEV_FOO",
+			Attributes: map[string]string{
+				"id":          "DEV.DEV_FOO",
+				"name":        "FOO",
+				"schema":      "DEV",
+				"object_name": "DEV_FOO",
+			},
+			RawConfig: cty.ObjectVal(map[string]cty.Value{
+				"name":   cty.StringVal("FOO"),
+				"schema": cty.NullVal(cty.String),
+			}),
+		}
+	}
+
+	tests := map[string]struct {
+		name        string
+		defaults    Defaults
+		requiresNew string
+	}{
+		"unchanged": {
+			defaults: Defaults{Schema: "DEV", ObjectPrefix: "DEV_"},
+		},
+		"rename": {
+			name:     "BAR",
+			defaults: Defaults{Schema: "DEV", ObjectPrefix: "DEV_"},
+		},
+		"schema": {
+			defaults:    Defaults{Schema: "PROD", ObjectPrefix: "DEV_"},
+			requiresNew: "schema",
+		},
+		"prefix": {
+			defaults:    Defaults{Schema: "DEV", ObjectPrefix: "PROD_"},
+			requiresNew: "object_name",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			name := test.name
+			if name == "" {
+				name = "FOO"
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name": name,
+			})
+			diff, err := r.Diff(context.TODO(), state(), config, test.defaults)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			if test.requiresNew == "" {
+				if diff != nil && diff.RequiresNew() {
+					t.Fatalf("Unexpected replacement: %#v", diff.Attributes)
+				}
+				return
+			}
+			if diff == nil || diff.Attributes[test.requiresNew] == nil || !diff.Attributes[test.requiresNew].RequiresNew {
+				t.Fatalf("Expected %s to require new Resource: %#v", test.requiresNew, diff)
+			}
+		})
+	}
+}
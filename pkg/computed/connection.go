@@ -3,10 +3,10 @@ package computed
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
 )
 
 // ReadConnection reads all attributes from Database.
@@ -24,7 +24,7 @@ func ReadConnection(ctx context.Context, d internal.Data, tx *sql.Tx) error {
 	}
 
 	if !r.Next() {
-		return fmt.Errorf("connection %s not found in Database", name)
+		return db.NewNotFoundError("CONNECTION", name)
 	}
 
 	var to string
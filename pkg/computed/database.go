@@ -0,0 +1,78 @@
+package computed
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Database describes the connected database and session
+type Database struct {
+	Name              string
+	Version           string
+	NodeCount         int
+	CurrentUser       string
+	CurrentSchema     string
+	SessionParameters map[string]string
+}
+
+// ReadDatabase reads metadata of the connected database
+func ReadDatabase(ctx context.Context, tx *sql.Tx) (*Database, error) {
+	res, err := tx.QueryContext(ctx, "SELECT PARAM_NAME, PARAM_VALUE FROM SYS.EXA_METADATA WHERE PARAM_NAME IN ('databaseName', 'databaseProductVersion')")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	db := &Database{
+		SessionParameters: map[string]string{},
+	}
+	for res.Next() {
+		var name string
+		var value string
+		err = res.Scan(&name, &value)
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "databaseName":
+			db.Name = value
+		case "databaseProductVersion":
+			db.Version = value
+		}
+	}
+	err = res.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	var currentSchema interface{}
+	var nodeCount float64
+	err = tx.QueryRowContext(ctx, "SELECT CURRENT_USER, CURRENT_SCHEMA, NPROC() FROM DUAL").Scan(&db.CurrentUser, &currentSchema, &nodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if currentSchema != nil {
+		db.CurrentSchema = currentSchema.(string)
+	}
+	db.NodeCount = int(nodeCount)
+
+	params, err := tx.QueryContext(ctx, "SELECT PARAMETER_NAME, SESSION_VALUE FROM SYS.EXA_PARAMETERS")
+	if err != nil {
+		return nil, err
+	}
+	defer params.Close()
+
+	for params.Next() {
+		var name string
+		var value interface{}
+		err = params.Scan(&name, &value)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		db.SessionParameters[name] = value.(string)
+	}
+	return db, params.Err()
+}
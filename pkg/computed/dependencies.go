@@ -0,0 +1,171 @@
+package computed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+)
+
+// ObjectRef identifies a Database object in a Schema
+type ObjectRef struct {
+	Schema string
+	Name   string
+	Type   string
+}
+
+// Dependency is an object found while walking the dependency graph.
+// Depth 1 are direct dependencies.
+type Dependency struct {
+	ObjectRef
+	Depth int
+}
+
+// Dependencies holds the objects an object references and the
+// objects that reference it
+type Dependencies struct {
+	References   []Dependency
+	ReferencedBy []Dependency
+}
+
+type dependencyEdge struct {
+	object     ObjectRef
+	referenced ObjectRef
+}
+
+// edgeReader reads the edges starting at any of objects
+type edgeReader func(objects []ObjectRef) ([]dependencyEdge, error)
+
+// ReadDependencies walks SYS.EXA_DBA_DEPENDENCIES from schema.name
+// in both directions for at most maxDepth levels. Every level only
+// reads the edges of the objects found on the previous level.
+// Returns a db.NotFoundError if the object does not exist.
+func ReadDependencies(ctx context.Context, tx *sql.Tx, schema, name string, maxDepth int) (*Dependencies, error) {
+	err := objectExists(ctx, tx, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	return walkDependencies(schema, name, maxDepth, queryEdges(ctx, tx, "OBJECT"), queryEdges(ctx, tx, "REFERENCED_OBJECT"))
+}
+
+func objectExists(ctx context.Context, tx *sql.Tx, schema, name string) error {
+	stmt := "SELECT OBJECT_TYPE FROM SYS.EXA_ALL_OBJECTS WHERE UPPER(ROOT_NAME) = UPPER(?) AND ROOT_TYPE = 'SCHEMA' AND UPPER(OBJECT_NAME) = UPPER(?)"
+	res, err := tx.QueryContext(ctx, stmt, schema, name)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	if !res.Next() {
+		err = res.Err()
+		if err != nil {
+			return err
+		}
+		return db.NewNotFoundError("OBJECT", resource.NewID(schema, name))
+	}
+	return nil
+}
+
+// queryEdges reads the edges whose column prefixed schema and name
+// match one of the objects
+func queryEdges(ctx context.Context, tx *sql.Tx, column string) edgeReader {
+	return func(objects []ObjectRef) ([]dependencyEdge, error) {
+		conds := make([]string, 0, len(objects))
+		args := make([]interface{}, 0, 2*len(objects))
+		for _, o := range objects {
+			conds = append(conds, fmt.Sprintf("(%[1]s_SCHEMA = ? AND %[1]s_NAME = ?)", column))
+			args = append(args, o.Schema, o.Name)
+		}
+		stmt := `SELECT OBJECT_SCHEMA, OBJECT_NAME, OBJECT_TYPE, REFERENCED_OBJECT_SCHEMA, REFERENCED_OBJECT_NAME, REFERENCED_OBJECT_TYPE
+FROM SYS.EXA_DBA_DEPENDENCIES WHERE ` + strings.Join(conds, " OR ")
+		res, err := tx.QueryContext(ctx, stmt, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Close()
+
+		edges := []dependencyEdge{}
+		for res.Next() {
+			var objectSchema, objectName, objectType sql.NullString
+			var refSchema, refName, refType sql.NullString
+			err = res.Scan(&objectSchema, &objectName, &objectType, &refSchema, &refName, &refType)
+			if err != nil {
+				return nil, err
+			}
+			edges = append(edges, dependencyEdge{
+				object: ObjectRef{
+					Schema: objectSchema.String,
+					Name:   objectName.String,
+					Type:   objectType.String,
+				},
+				referenced: ObjectRef{
+					Schema: refSchema.String,
+					Name:   refName.String,
+					Type:   refType.String,
+				},
+			})
+		}
+		return edges, res.Err()
+	}
+}
+
+func walkDependencies(schema, name string, maxDepth int, references, referencedBy edgeReader) (*Dependencies, error) {
+	root := ObjectRef{
+		Schema: strings.ToUpper(schema),
+		Name:   strings.ToUpper(name),
+	}
+	deps := &Dependencies{}
+	var err error
+	deps.References, err = walk(root, maxDepth, references, func(e dependencyEdge) (ObjectRef, ObjectRef) {
+		return e.object, e.referenced
+	})
+	if err != nil {
+		return nil, err
+	}
+	deps.ReferencedBy, err = walk(root, maxDepth, referencedBy, func(e dependencyEdge) (ObjectRef, ObjectRef) {
+		return e.referenced, e.object
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// walk does a breadth first search so every object gets its minimal depth
+func walk(root ObjectRef, maxDepth int, read edgeReader, direction func(dependencyEdge) (from, to ObjectRef)) ([]Dependency, error) {
+	key := func(o ObjectRef) string {
+		return strings.ToUpper(o.Schema) + "." + strings.ToUpper(o.Name)
+	}
+
+	found := []Dependency{}
+	visited := map[string]bool{
+		key(root): true,
+	}
+	current := []ObjectRef{root}
+	for depth := 1; depth <= maxDepth && len(current) != 0; depth++ {
+		edges, err := read(current)
+		if err != nil {
+			return nil, err
+		}
+		var next []ObjectRef
+		for _, o := range current {
+			for _, e := range edges {
+				from, to := direction(e)
+				if key(from) != key(o) || visited[key(to)] {
+					continue
+				}
+				visited[key(to)] = true
+				found = append(found, Dependency{
+					ObjectRef: to,
+					Depth:     depth,
+				})
+				next = append(next, to)
+			}
+		}
+		current = next
+	}
+	return found, nil
+}
@@ -0,0 +1,84 @@
+package computed
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWalkDependencies(t *testing.T) {
+	ref := func(name, typ string) ObjectRef {
+		return ObjectRef{
+			Schema: "S",
+			Name:   name,
+			Type:   typ,
+		}
+	}
+	edges := []dependencyEdge{
+		{object: ref("V1", "VIEW"), referenced: ref("T1", "TABLE")},
+		{object: ref("V2", "VIEW"), referenced: ref("V1", "VIEW")},
+		{object: ref("V3", "VIEW"), referenced: ref("V2", "VIEW")},
+		{object: ref("V3", "VIEW"), referenced: ref("V1", "VIEW")},
+		{object: ref("V4", "VIEW"), referenced: ref("T2", "TABLE")},
+	}
+	reads := [][]ObjectRef{}
+	read := func(objects []ObjectRef) ([]dependencyEdge, error) {
+		reads = append(reads, objects)
+		return edges, nil
+	}
+	walkDependencies := func(schema, name string, maxDepth int) *Dependencies {
+		reads = nil
+		deps, err := walkDependencies(schema, name, maxDepth, read, read)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		return deps
+	}
+
+	deps := walkDependencies("s", "v2", 10)
+	expectedReferences := []Dependency{
+		{ObjectRef: ref("V1", "VIEW"), Depth: 1},
+		{ObjectRef: ref("T1", "TABLE"), Depth: 2},
+	}
+	d := cmp.Diff(deps.References, expectedReferences)
+	if d != "" {
+		t.Fatal("Unexpected references:", d)
+	}
+	expectedReferencedBy := []Dependency{
+		{ObjectRef: ref("V3", "VIEW"), Depth: 1},
+	}
+	d = cmp.Diff(deps.ReferencedBy, expectedReferencedBy)
+	if d != "" {
+		t.Fatal("Unexpected referenced by:", d)
+	}
+
+	deps = walkDependencies("S", "T1", 1)
+	expectedReferencedBy = []Dependency{
+		{ObjectRef: ref("V1", "VIEW"), Depth: 1},
+	}
+	d = cmp.Diff(deps.ReferencedBy, expectedReferencedBy)
+	if d != "" {
+		t.Fatal("Unexpected referenced by with depth 1:", d)
+	}
+	if len(deps.References) != 0 {
+		t.Fatalf("Unexpected references of Table: %#v", deps.References)
+	}
+	d = cmp.Diff(reads, [][]ObjectRef{
+		{{Schema: "S", Name: "T1"}},
+		{{Schema: "S", Name: "T1"}},
+	})
+	if d != "" {
+		t.Fatal("Expected only edges of root to be read with depth 1:", d)
+	}
+
+	deps = walkDependencies("S", "T1", 10)
+	expectedReferencedBy = []Dependency{
+		{ObjectRef: ref("V1", "VIEW"), Depth: 1},
+		{ObjectRef: ref("V2", "VIEW"), Depth: 2},
+		{ObjectRef: ref("V3", "VIEW"), Depth: 2},
+	}
+	d = cmp.Diff(deps.ReferencedBy, expectedReferencedBy)
+	if d != "" {
+		t.Fatal("Unexpected transitive referenced by:", d)
+	}
+}
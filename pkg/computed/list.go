@@ -0,0 +1,83 @@
+package computed
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ObjectSummary describes one Table or View of a Schema
+type ObjectSummary struct {
+	Name    string
+	Owner   string
+	Comment string
+	// RowCount is only available for Tables
+	RowCount int
+	// Columns in the same format as TableReader.Columns
+	Columns []interface{}
+}
+
+// ReadTables reads all Tables of schema including their Columns
+// with a single metadata query
+func ReadTables(ctx context.Context, tx *sql.Tx, schema string) ([]*ObjectSummary, error) {
+	stmt := `SELECT T.TABLE_NAME, T.TABLE_OWNER, T.TABLE_COMMENT, T.TABLE_ROW_COUNT, C.COLUMN_NAME, C.COLUMN_TYPE, C.COLUMN_COMMENT
+FROM SYS.EXA_ALL_TABLES T
+LEFT JOIN SYS.EXA_ALL_COLUMNS C ON C.COLUMN_SCHEMA = T.TABLE_SCHEMA AND C.COLUMN_TABLE = T.TABLE_NAME
+WHERE UPPER(T.TABLE_SCHEMA) = UPPER(?)
+ORDER BY T.TABLE_NAME, C.COLUMN_ORDINAL_POSITION`
+	return readObjectSummaries(ctx, tx, stmt, schema)
+}
+
+// ReadViews reads all Views of schema including their Columns
+// with a single metadata query
+func ReadViews(ctx context.Context, tx *sql.Tx, schema string) ([]*ObjectSummary, error) {
+	stmt := `SELECT V.VIEW_NAME, V.VIEW_OWNER, V.VIEW_COMMENT, NULL, C.COLUMN_NAME, C.COLUMN_TYPE, C.COLUMN_COMMENT
+FROM SYS.EXA_ALL_VIEWS V
+LEFT JOIN SYS.EXA_ALL_COLUMNS C ON C.COLUMN_SCHEMA = V.VIEW_SCHEMA AND C.COLUMN_TABLE = V.VIEW_NAME
+WHERE UPPER(V.VIEW_SCHEMA) = UPPER(?)
+ORDER BY V.VIEW_NAME, C.COLUMN_ORDINAL_POSITION`
+	return readObjectSummaries(ctx, tx, stmt, schema)
+}
+
+func readObjectSummaries(ctx context.Context, tx *sql.Tx, stmt, schema string) ([]*ObjectSummary, error) {
+	res, err := tx.QueryContext(ctx, stmt, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	objects := []*ObjectSummary{}
+	var current *ObjectSummary
+	for res.Next() {
+		var name string
+		var owner, comment, columnName, columnType, columnComment sql.NullString
+		var rowCount sql.NullFloat64
+		err = res.Scan(&name, &owner, &comment, &rowCount, &columnName, &columnType, &columnComment)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil || current.Name != name {
+			current = &ObjectSummary{
+				Name:     name,
+				Owner:    owner.String,
+				Comment:  comment.String,
+				RowCount: int(rowCount.Float64 + 0.5),
+				Columns:  []interface{}{},
+			}
+			objects = append(objects, current)
+		}
+
+		if !columnName.Valid {
+			continue
+		}
+		col := map[string]interface{}{
+			"name": columnName.String,
+			"type": columnType.String,
+		}
+		if columnComment.Valid {
+			col["comment"] = columnComment.String
+		}
+		current.Columns = append(current.Columns, col)
+	}
+	return objects, res.Err()
+}
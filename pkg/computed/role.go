@@ -0,0 +1,50 @@
+package computed
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+)
+
+// ReadRole reads comment and created from Database and sets the id.
+// Returns a db.NotFoundError if the Role does not exist.
+func ReadRole(ctx context.Context, d internal.Data, tx *sql.Tx) error {
+
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+
+	r, err := tx.QueryContext(ctx, "SELECT ROLE_NAME, ROLE_COMMENT, CREATED FROM SYS.EXA_DBA_ROLES WHERE UPPER(ROLE_NAME) = UPPER(?)", name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if !r.Next() {
+		return db.NewNotFoundError("ROLE", name)
+	}
+
+	var roleName string
+	var comment sql.NullString
+	var created sql.NullString
+	err = r.Scan(&roleName, &comment, &created)
+	if err != nil {
+		return err
+	}
+
+	err = setComment(comment.String, d)
+	if err != nil {
+		return err
+	}
+	err = d.Set("created", created.String)
+	if err != nil {
+		return err
+	}
+	d.SetId(strings.ToUpper(roleName))
+	return nil
+}
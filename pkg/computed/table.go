@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -87,20 +89,21 @@ func PrimaryKeysSchema() *schema.Schema {
 	}
 }
 
-// ReadTable reads necessary information of a Table
+// ReadTable reads necessary information of a Table.
+// Returns a db.NotFoundError if the Table does not exist.
 func ReadTable(ctx context.Context, tx *sql.Tx, schema, table string) (*TableReader, error) {
 	tr := &TableReader{}
 	var err error
-	tcs, err := readTableColumns(ctx, tx, schema, table)
+	tr.Comment, err = readComment(ctx, tx, schema, table)
 	if err != nil {
 		return nil, err
 	}
-	tr.Columns = tcs.cols
-	tr.ColumnIndices = tcs.indices
-	tr.Comment, err = readComment(ctx, tx, schema, table)
+	tcs, err := readTableColumns(ctx, tx, schema, table)
 	if err != nil {
 		return nil, err
 	}
+	tr.Columns = tcs.cols
+	tr.ColumnIndices = tcs.indices
 	tr.PrimaryKeys, err = readPrimaryKeys(ctx, tx, schema, table)
 	if err != nil {
 		return nil, err
@@ -165,7 +168,7 @@ func readComment(ctx context.Context, tx *sql.Tx, schema, name string) (string,
 	}
 
 	if !res.Next() {
-		return "", nil
+		return "", db.NewNotFoundError("TABLE", resource.NewID(schema, name))
 	}
 
 	var comment interface{}
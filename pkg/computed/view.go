@@ -11,18 +11,16 @@ import (
 	"unicode/utf8"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/pkg/errors"
 )
 
 var (
-	columnReg             = regexp.MustCompile(`(?s)CREATE\s+(?:OR\s+REPLACE|FORCE)?\s?VIEW\s+.*?\s+\((.*)\)\s+AS`)
-	subqueryReg           = regexp.MustCompile(`(?s)CREATE\s+(?:OR\s+REPLACE|FORCE)?\s?VIEW\s+.*?AS\s+(.*?)(?:\s+COMMENT\s+IS.*)?$`)
-	ReadViewNoResultError = &readViewNoResultError{}
+	columnReg   = regexp.MustCompile(`(?s)CREATE\s+(?:OR\s+REPLACE|FORCE)?\s?VIEW\s+.*?\s+\((.*)\)\s+AS`)
+	subqueryReg = regexp.MustCompile(`(?s)CREATE\s+(?:OR\s+REPLACE|FORCE)?\s?VIEW\s+.*?AS\s+(.*?)(?:\s+COMMENT\s+IS.*)?$`)
 )
 
-type readViewNoResultError struct {
-}
-
 type View struct {
 	Comment  string
 	Columns  []ViewColumn
@@ -34,10 +32,6 @@ type ViewColumn struct {
 	Comment string
 }
 
-func (err *readViewNoResultError) Error() string {
-	return ""
-}
-
 func (v *View) SetComment(d internal.Data) error {
 	return setComment(v.Comment, d)
 }
@@ -67,7 +61,7 @@ func ReadView(ctx context.Context, tx *sql.Tx, schema, name string) (*View, erro
 	}
 
 	if !res.Next() {
-		return nil, fmt.Errorf("selecting View Metadata for %s.%s resulted in no result%w", schema, name, ReadViewNoResultError)
+		return nil, db.NewNotFoundError("VIEW", resource.NewID(schema, name))
 	}
 
 	var c interface{}
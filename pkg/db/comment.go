@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 // Comment changes the comment on the Database object
@@ -12,3 +13,11 @@ func Comment(tx *sql.Tx, t, objectName, newComment, schema string) error {
 	_, err := tx.Exec(stmt)
 	return err
 }
+
+// CommentGlobal changes the comment on a global Database object (e.g. Role)
+func CommentGlobal(tx *sql.Tx, t, objectName, newComment string) error {
+
+	stmt := fmt.Sprintf("COMMENT ON %s %s IS '%s'", t, objectName, strings.ReplaceAll(newComment, "'", "''"))
+	_, err := tx.Exec(stmt)
+	return err
+}
@@ -1,7 +1,39 @@
 package db
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrorNamedObjectNotFound = errors.New("Named object not found on database")
 )
+
+// NotFoundError indicates that an object of Type with Name does not
+// exist on the Database. It matches ErrorNamedObjectNotFound in errors.Is.
+type NotFoundError struct {
+	Type string
+	Name string
+}
+
+// NewNotFoundError creates a NotFoundError for object type t
+// (e.g. TABLE) and name
+func NewNotFoundError(t, name string) error {
+	return &NotFoundError{
+		Type: t,
+		Name: name,
+	}
+}
+
+func (err *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found on database", err.Type, err.Name)
+}
+
+func (err *NotFoundError) Is(target error) bool {
+	return target == ErrorNamedObjectNotFound
+}
+
+// IsNotFound reports whether err signals a missing object
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrorNamedObjectNotFound)
+}
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("reading failed: %w", NewNotFoundError("TABLE", "FOO.BAR"))
+	if !IsNotFound(err) {
+		t.Fatal("Expected wrapped NotFoundError to be not found")
+	}
+	if !errors.Is(err, ErrorNamedObjectNotFound) {
+		t.Fatal("Expected NotFoundError to match ErrorNamedObjectNotFound")
+	}
+
+	nf := &NotFoundError{}
+	if !errors.As(err, &nf) || nf.Name != "FOO.BAR" {
+		t.Fatalf("Unexpected NotFoundError: %#v", nf)
+	}
+
+	if IsNotFound(errors.New("foo")) {
+		t.Fatal("Expected other errors not to be not found")
+	}
+	if err.Error() != "reading failed: TABLE FOO.BAR not found on database" {
+		t.Fatalf("Unexpected message: %s", err)
+	}
+}
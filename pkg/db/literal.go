@@ -0,0 +1,15 @@
+package db
+
+import "strings"
+
+// StringLiteral renders v as string literal, e.g. for LDAP
+// distinguished names
+func StringLiteral(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+// QuotedIdentifier renders v as delimited identifier, e.g. for
+// passwords of Users
+func QuotedIdentifier(v string) string {
+	return `"` + strings.ReplaceAll(v, `"`, `""`) + `"`
+}
@@ -0,0 +1,12 @@
+package db
+
+import "testing"
+
+func TestLiterals(t *testing.T) {
+	if l := StringLiteral("cn=o'brien"); l != "'cn=o''brien'" {
+		t.Errorf("Unexpected string literal: %s", l)
+	}
+	if i := QuotedIdentifier(`se"cret`); i != `"se""cret"` {
+		t.Errorf("Unexpected quoted identifier: %s", i)
+	}
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParameterValue renders v for ALTER SESSION and ALTER SYSTEM.
+// Integers are kept as is, everything else becomes a string literal.
+func ParameterValue(v string) string {
+	_, err := strconv.Atoi(v)
+	if err == nil {
+		return v
+	}
+	return StringLiteral(v)
+}
+
+// ReadSystemParameter reads the system wide value of parameter name
+func ReadSystemParameter(ctx context.Context, tx *sql.Tx, name string) (string, error) {
+	r, err := tx.QueryContext(ctx, "SELECT SYSTEM_VALUE FROM SYS.EXA_PARAMETERS WHERE PARAMETER_NAME = UPPER(?)", name)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	if !r.Next() {
+		return "", NewNotFoundError("PARAMETER", name)
+	}
+	var value sql.NullString
+	err = r.Scan(&value)
+	return value.String, err
+}
+
+// SetSystemParameter changes the system wide value of parameter name
+func SetSystemParameter(tx *sql.Tx, name, value string) error {
+	stmt := fmt.Sprintf("ALTER SYSTEM SET %s = %s", strings.ToUpper(name), ParameterValue(value))
+	_, err := tx.Exec(stmt)
+	return err
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version of an Exasol database, e.g. 7.1.2
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses databaseProductVersion of EXA_METADATA.
+// Missing minor or patch parts default to 0.
+func ParseVersion(s string) (Version, error) {
+	v := Version{}
+	parts := strings.SplitN(strings.TrimSpace(s), ".", 3)
+	fields := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		// Ignore suffixes like 7.1.2-rc1
+		end := strings.IndexFunc(p, func(r rune) bool {
+			return r < '0' || r > '9'
+		})
+		if end != -1 {
+			p = p[:end]
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %s: %w", s, err)
+		}
+		*fields[i] = n
+		if end != -1 {
+			break
+		}
+	}
+	return v, nil
+}
+
+// AtLeast reports whether v is major.minor or newer
+func (v Version) AtLeast(major, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// ReadVersion reads the version of the connected database
+func ReadVersion(ctx context.Context, tx *sql.Tx) (Version, error) {
+	r := tx.QueryRowContext(ctx, "SELECT PARAM_VALUE FROM SYS.EXA_METADATA WHERE PARAM_NAME = 'databaseProductVersion'")
+	var s string
+	err := r.Scan(&s)
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(s)
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Version{
+		"7.1.2":     {Major: 7, Minor: 1, Patch: 2},
+		"6.2":       {Major: 6, Minor: 2},
+		"8":         {Major: 8},
+		"7.1.2-rc1": {Major: 7, Minor: 1, Patch: 2},
+		"7.1rc1":    {Major: 7, Minor: 1},
+	}
+	for s, expected := range tests {
+		v, err := ParseVersion(s)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", s, err)
+		}
+		if v != expected {
+			t.Errorf("Expected %s for %s: %s", expected, s, v)
+		}
+	}
+
+	_, err := ParseVersion("foo")
+	if err == nil {
+		t.Fatal("Expected error for invalid version")
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	t.Parallel()
+
+	v := Version{Major: 7, Minor: 1, Patch: 2}
+	if !v.AtLeast(7, 1) || !v.AtLeast(7, 0) || !v.AtLeast(6, 2) {
+		t.Fatalf("Expected %s to be at least 7.1, 7.0 and 6.2", v)
+	}
+	if v.AtLeast(7, 2) || v.AtLeast(8, 0) {
+		t.Fatalf("Expected %s to be older than 7.2 and 8.0", v)
+	}
+}
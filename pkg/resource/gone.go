@@ -0,0 +1,25 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/logging"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+)
+
+// RemoveIfNotFound removes d from state when err signals that the object
+// was dropped outside of Terraform, so the next plan recreates it.
+// Any other err is returned unchanged.
+func RemoveIfNotFound(ctx context.Context, d internal.Data, err error) error {
+	if !db.IsNotFound(err) {
+		return err
+	}
+
+	logging.Warn(ctx, "Object not found on database, removing it from state", map[string]interface{}{
+		"id":    d.Id(),
+		"error": err.Error(),
+	})
+	d.SetId("")
+	return nil
+}
@@ -24,3 +24,8 @@ func SplitIDInSchema(id string) (schema, name string, err error) {
 	name = parts[1]
 	return
 }
+
+// NewListID creates an id for list Data Sources from their filters
+func NewListID(kind string, filters ...string) string {
+	return fmt.Sprintf("%s/%s", kind, strings.Join(filters, "/"))
+}
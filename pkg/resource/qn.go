@@ -29,3 +29,12 @@ func GetMetaFromQNDefault(qn, schemaDefault string) (meta DatabaseMeta, err erro
 	meta.ObjectName = parts[1]
 	return
 }
+
+// TrimPrefix removes the object prefix from name ignoring case.
+// Should name not start with prefix it is returned as is
+func TrimPrefix(name, prefix string) string {
+	if len(name) < len(prefix) || !strings.EqualFold(name[:len(prefix)], prefix) {
+		return name
+	}
+	return name[len(prefix):]
+}
@@ -42,3 +42,20 @@ func TestGetMetaFromQNDefault(t *testing.T) {
 		t.Fatalf("Unexpected table (expected tableBar): %s", m.ObjectName)
 	}
 }
+
+func TestTrimPrefix(t *testing.T) {
+	n := TrimPrefix("DEV_ORDERS", "dev_")
+	if n != "ORDERS" {
+		t.Fatalf("Unexpected name (expected ORDERS): %s", n)
+	}
+
+	n = TrimPrefix("ORDERS", "dev_")
+	if n != "ORDERS" {
+		t.Fatalf("Unexpected name (expected ORDERS): %s", n)
+	}
+
+	n = TrimPrefix("ORDERS", "")
+	if n != "ORDERS" {
+		t.Fatalf("Unexpected name (expected ORDERS): %s", n)
+	}
+}
@@ -0,0 +1,53 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// UpgradeGlobalIDV0 upgrades state of global objects (e.g. Roles, Users)
+// from schema version 0. Ids are normalized to upper case.
+func UpgradeGlobalIDV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	id, _ := rawState["id"].(string)
+	if id != "" {
+		rawState["id"] = strings.ToUpper(id)
+	}
+	return rawState, nil
+}
+
+// UpgradeSchemaObjectIDV0 upgrades state of objects in a Schema
+// (e.g. Tables, Views) from schema version 0. Ids are rebuilt via NewID
+// and a missing schema attribute is taken from the id.
+func UpgradeSchemaObjectIDV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	id, _ := rawState["id"].(string)
+	if id == "" {
+		return rawState, nil
+	}
+
+	schema, name, err := SplitIDInSchema(id)
+	if err != nil {
+		return nil, fmt.Errorf("upgrading state failed: %w", err)
+	}
+	rawState["id"] = NewID(schema, name)
+
+	if s, _ := rawState["schema"].(string); s == "" {
+		rawState["schema"] = schema
+	}
+	return rawState, nil
+}
+
+// DropAttributes removes attributes which are no longer part of the schema
+func DropAttributes(rawState map[string]interface{}, names ...string) {
+	for _, name := range names {
+		delete(rawState, name)
+	}
+}
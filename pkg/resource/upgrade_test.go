@@ -0,0 +1,65 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func historicState(t *testing.T, content string) map[string]interface{} {
+	rawState := map[string]interface{}{}
+	err := json.Unmarshal([]byte(content), &rawState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rawState
+}
+
+func TestUpgradeGlobalIDV0(t *testing.T) {
+	t.Parallel()
+
+	rawState := historicState(t, `{"id": "foo_role", "name": "foo_role"}`)
+
+	upgraded, err := UpgradeGlobalIDV0(context.TODO(), rawState, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if upgraded["id"] != "FOO_ROLE" {
+		t.Fatalf("Unexpected id: %#v", upgraded["id"])
+	}
+	if upgraded["name"] != "foo_role" {
+		t.Fatalf("Unexpected name: %#v", upgraded["name"])
+	}
+}
+
+func TestUpgradeSchemaObjectIDV0(t *testing.T) {
+	t.Parallel()
+
+	rawState := historicState(t, `{"id": "foo.bar", "name": "bar", "schema": ""}`)
+
+	upgraded, err := UpgradeSchemaObjectIDV0(context.TODO(), rawState, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if upgraded["id"] != "FOO.BAR" {
+		t.Fatalf("Unexpected id: %#v", upgraded["id"])
+	}
+	if upgraded["schema"] != "foo" {
+		t.Fatalf("Unexpected schema: %#v", upgraded["schema"])
+	}
+
+	rawState = historicState(t, `{"id": "FOO.BAR", "name": "bar", "schema": "Foo"}`)
+
+	upgraded, err = UpgradeSchemaObjectIDV0(context.TODO(), rawState, nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if upgraded["schema"] != "Foo" {
+		t.Fatalf("Unexpected schema: %#v", upgraded["schema"])
+	}
+
+	_, err = UpgradeSchemaObjectIDV0(context.TODO(), historicState(t, `{"id": "BAR"}`), nil)
+	if err == nil {
+		t.Fatal("Expected error for id without Schema")
+	}
+}